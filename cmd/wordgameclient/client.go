@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fantashley/wordgame-controller/pkg/wordgameclient"
+	"github.com/google/uuid"
+)
+
+// requestTimeout is how long to wait for the server to answer a request, so
+// that a slow or stuck server does not hang the prompt
+const requestTimeout = 10 * time.Second
+
+// client talks to a word game server on behalf of one player
+type client struct {
+	api      *wordgameclient.Client
+	gameID   uuid.UUID
+	playerID uuid.UUID
+	color    bool // ask for boards colored with ANSI escape codes
+	started  bool // game has started, so there is a board to draw
+}
+
+// timeout returns a context for a single request to the server
+func timeout() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), requestTimeout)
+}
+
+// create creates a new game and joins it as the host
+func (c *client) create(name string) error {
+	ctx, cancel := timeout()
+	defer cancel()
+
+	resp, err := c.api.CreateGame(ctx, wordgameclient.GeneralGameRequest{PlayerName: &name})
+	if err != nil {
+		return err
+	} else if resp.PlayerID == nil {
+		return errors.New("Server did not add the player to the game")
+	}
+
+	c.gameID, c.playerID = resp.GameID, *resp.PlayerID
+	return nil
+}
+
+// join joins an existing game
+func (c *client) join(gameID uuid.UUID, name string) error {
+	ctx, cancel := timeout()
+	defer cancel()
+
+	resp, err := c.api.JoinGame(ctx, wordgameclient.GeneralGameRequest{
+		GameID:     gameID,
+		PlayerName: &name,
+	})
+	if err != nil {
+		return err
+	} else if resp.PlayerID == nil {
+		return errors.New("Server did not add the player to the game")
+	}
+
+	c.gameID, c.playerID = gameID, *resp.PlayerID
+	return nil
+}
+
+// request returns a request naming the client's game and player
+func (c *client) request() wordgameclient.GeneralGameRequest {
+	return wordgameclient.GeneralGameRequest{
+		GameID:   c.gameID,
+		PlayerID: &c.playerID,
+	}
+}
+
+// start starts the game. Only the host can start it.
+func (c *client) start() error {
+	ctx, cancel := timeout()
+	defer cancel()
+
+	return c.api.StartGame(ctx, c.request())
+}
+
+// stateText returns the game state drawn as text
+func (c *client) stateText() (string, error) {
+	ctx, cancel := timeout()
+	defer cancel()
+
+	return c.api.GetGameStateText(ctx, c.request(), c.color)
+}
+
+// play plays a move in standard notation, which may be a swap or a pass
+func (c *client) play(move string) error {
+	ctx, cancel := timeout()
+	defer cancel()
+
+	_, err := c.api.PlayMove(ctx, wordgameclient.GamePlayRequest{
+		GameID:   c.gameID,
+		PlayerID: c.playerID,
+		Move:     move,
+	})
+	return err
+}
+
+// myTurn reports whether the player is up next
+func (c *client) myTurn() (bool, error) {
+	ctx, cancel := timeout()
+	defer cancel()
+
+	entries, err := c.api.GetInbox(ctx, wordgameclient.InboxRequest{
+		PlayerIDs: []uuid.UUID{c.playerID},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, e := range entries {
+		if e.GameID == c.gameID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// say posts a message to the game's chat
+func (c *client) say(text string) error {
+	ctx, cancel := timeout()
+	defer cancel()
+
+	_, err := c.api.PostChat(ctx, wordgameclient.ChatRequest{
+		GameID:   c.gameID,
+		PlayerID: &c.playerID,
+		Text:     text,
+	})
+	return err
+}
+
+// watch streams the game's server-sent events until the context is done or
+// the connection is lost
+func (c *client) watch(ctx context.Context, events chan<- wordgameclient.Event) error {
+	return c.api.WatchGame(ctx, c.gameID, &c.playerID, events)
+}
@@ -0,0 +1,232 @@
+// Command wordgameclient plays word games from the terminal. It creates or
+// joins a game on a wordgameserver, shows the board and rack, and redraws them
+// whenever the game changes.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fantashley/wordgame-controller/pkg/wordgameclient"
+	"github.com/google/uuid"
+)
+
+const help = `Commands:
+  create          create a game and join it as host
+  join GAME-ID    join an existing game
+  start           start the game (host only)
+  8H WORD         play WORD across from 8H, or down with H8 WORD
+                  letters in parentheses are already on the board,
+                  lowercase letters are blanks
+  swap LETTERS    swap tiles, with ? for a blank
+  pass            pass your turn
+  board           show the board again
+  say TEXT        send a chat message
+  help            show this help
+  quit            leave the client
+`
+
+// retryDelay is how long to wait before reconnecting to the game's updates
+const retryDelay = 2 * time.Second
+
+func main() {
+	server := flag.String("server", "http://localhost:8080", "URL of the word game server")
+	name := flag.String("name", os.Getenv("USER"), "name to play under")
+	game := flag.String("game", "", "game to join on start up")
+	player := flag.String("player", "", "player ID to rejoin -game with, instead of joining as a new player")
+	color := flag.Bool("color", true, "draw the board with ANSI colors")
+	flag.Parse()
+
+	c := &client{api: wordgameclient.New(*server), color: *color}
+
+	if *game != "" {
+		gameID, err := uuid.Parse(*game)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Invalid game ID:", err)
+			os.Exit(2)
+		}
+
+		if *player != "" {
+			c.gameID = gameID
+			if c.playerID, err = uuid.Parse(*player); err != nil {
+				fmt.Fprintln(os.Stderr, "Invalid player ID:", err)
+				os.Exit(2)
+			}
+		} else if err = c.join(gameID, *name); err != nil {
+			fmt.Fprintln(os.Stderr, "Could not join game:", err)
+			os.Exit(1)
+		}
+	}
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	events := make(chan wordgameclient.Event)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if c.gameID != uuid.Nil {
+		c.joined(ctx, events)
+	} else {
+		fmt.Print(help)
+	}
+	prompt()
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			wasWatching := c.gameID != uuid.Nil
+			if quit := c.command(line, *name); quit {
+				return
+			}
+			if !wasWatching && c.gameID != uuid.Nil {
+				c.joined(ctx, events)
+			}
+			prompt()
+		case e := <-events:
+			c.update(e)
+		}
+	}
+}
+
+// prompt asks for the next command
+func prompt() {
+	fmt.Print("> ")
+}
+
+// joined announces the game the client is playing in and starts watching it
+// for updates
+func (c *client) joined(ctx context.Context, events chan<- wordgameclient.Event) {
+	fmt.Printf("Playing in game %s as player %s\n", c.gameID, c.playerID)
+	fmt.Printf("Rejoin with: -game %s -player %s\n", c.gameID, c.playerID)
+
+	go func() {
+		for {
+			if err := c.watch(ctx, events); ctx.Err() != nil {
+				return
+			} else if err != nil {
+				fmt.Fprintln(os.Stderr, "\nLost connection to game updates:", err)
+			}
+			time.Sleep(retryDelay)
+		}
+	}()
+}
+
+// update handles an event from the game being watched
+func (c *client) update(e wordgameclient.Event) {
+	switch e.Name {
+	case "state":
+		// Spectator updates are only sent once the game has started
+		c.started = true
+		c.show()
+		if mine, err := c.myTurn(); err == nil && mine {
+			fmt.Println("It's your turn!")
+		}
+		prompt()
+	case "chat":
+		var m wordgameclient.ChatMessage
+		if err := json.Unmarshal(e.Data, &m); err == nil {
+			fmt.Printf("\n[%s] %s\n", m.Sender, m.Text)
+			prompt()
+		}
+	}
+}
+
+// show draws the board, scores and rack. There is nothing to draw until the
+// game has started.
+func (c *client) show() {
+	if !c.started {
+		fmt.Println("The game has not started yet")
+		return
+	}
+
+	text, err := c.stateText()
+	if err != nil {
+		fmt.Println("Could not get game state:", err)
+		return
+	}
+
+	if c.color {
+		// Clear the screen before redrawing
+		fmt.Print("\x1b[H\x1b[2J")
+	}
+	fmt.Print("\n" + text)
+}
+
+// command runs a line typed by the player and reports whether they asked to
+// quit
+func (c *client) command(line, name string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+
+	inGame := c.gameID != uuid.Nil
+	var err error
+	switch cmd := strings.ToLower(fields[0]); {
+	case cmd == "quit" || cmd == "exit":
+		return true
+	case cmd == "help":
+		fmt.Print(help)
+	case cmd == "create" || cmd == "join":
+		if inGame {
+			fmt.Println("Already playing in a game")
+			return false
+		} else if name == "" {
+			fmt.Println("Set a name to play under with -name")
+			return false
+		}
+		if cmd == "create" {
+			err = c.create(name)
+		} else if len(fields) != 2 {
+			fmt.Println("Usage: join GAME-ID")
+			return false
+		} else {
+			var gameID uuid.UUID
+			if gameID, err = uuid.Parse(fields[1]); err == nil {
+				err = c.join(gameID, name)
+			}
+		}
+	case !inGame:
+		fmt.Println("Create or join a game first")
+		return false
+	case cmd == "start":
+		err = c.start()
+	case cmd == "board":
+		c.show()
+	case cmd == "pass":
+		err = c.play("-")
+	case cmd == "swap":
+		if len(fields) != 2 {
+			fmt.Println("Usage: swap LETTERS")
+			return false
+		}
+		err = c.play("-" + fields[1])
+	case cmd == "say":
+		err = c.say(strings.TrimSpace(line[len(fields[0]):]))
+	case len(fields) == 2:
+		err = c.play(fields[0] + " " + fields[1])
+	default:
+		fmt.Println("Unknown command. Type help for a list of commands.")
+	}
+
+	if err != nil {
+		fmt.Println("Error:", err)
+	}
+	return false
+}
@@ -1,12 +1,44 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"github.com/fantashley/wordgame-controller/pkg/wordgameserver"
 )
 
+// dictionaryFlag loads a word list each time the flag is given
+type dictionaryFlag struct{}
+
+func (dictionaryFlag) String() string { return "" }
+
+func (dictionaryFlag) Set(path string) error {
+	d, err := wordgameserver.LoadDictionary(path)
+	if err != nil {
+		return err
+	}
+	log.Printf("Loaded dictionary %v with %v words", d.Name, d.Len())
+	return nil
+}
+
 func main() {
+	addr := flag.String("addr", ":8080", "address for the HTTP server to listen on")
+	grpcAddr := flag.String("grpc-addr", ":9090", "address for the gRPC server to listen on, empty to disable it")
+	flag.Var(dictionaryFlag{}, "dictionary", "word list with one word per line, named after the file (repeatable)")
+	saveDir := flag.String("save-dir", "", "directory to save correspondence games and study cardboxes in and restore them from, empty to keep them in memory only")
+	flag.Parse()
+
+	if *saveDir != "" {
+		if err := wordgameserver.LoadGames(*saveDir); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *grpcAddr != "" {
+		go func() {
+			log.Fatal(wordgameserver.StartGRPCServer(*grpcAddr))
+		}()
+	}
 
-	log.Fatal(wordgameserver.StartWordGameServer(":8080"))
+	log.Fatal(wordgameserver.StartWordGameServer(*addr))
 }
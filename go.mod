@@ -1,9 +1,12 @@
 module github.com/fantashley/wordgame-controller
 
-go 1.14
+go 1.16
 
 require (
-	github.com/google/uuid v1.1.1
+	github.com/google/uuid v1.1.2
 	github.com/gorilla/mux v1.7.4
 	github.com/pkg/errors v0.9.1
+	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
 )
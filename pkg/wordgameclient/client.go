@@ -0,0 +1,102 @@
+// Package wordgameclient is a typed client for the word game server's HTTP
+// API, as described by the OpenAPI document the server serves at
+// /openapi.json.
+package wordgameclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Client sends requests to a word game server
+type Client struct {
+	BaseURL    string       // such as http://localhost:8080
+	HTTPClient *http.Client // http.DefaultClient if nil
+}
+
+// New creates a client for the server at the base URL
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Error is returned when the server responds with an error status
+type Error struct {
+	StatusCode int    // HTTP status of the response
+	Code       string // stable reason given by the server, such as NOT_YOUR_TURN
+	Message    string // reason given by the server
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// send sends a request and returns the response body. Responses with an error
+// status are returned as an *Error from the ErrorResponse in the body, or from
+// its first line if it is not one.
+func (c *Client) send(ctx context.Context, method, path, contentType string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequest(method, c.BaseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	r, err := c.httpClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.StatusCode >= http.StatusBadRequest {
+		var resp ErrorResponse
+		if err := json.Unmarshal(data, &resp); err == nil && resp.Error.Code != "" {
+			return nil, &Error{StatusCode: r.StatusCode, Code: resp.Error.Code, Message: resp.Error.Message}
+		}
+
+		msg := strings.TrimSpace(strings.SplitN(string(data), "\n", 2)[0])
+		if msg == "" {
+			msg = r.Status
+		}
+		return nil, &Error{StatusCode: r.StatusCode, Message: msg}
+	}
+
+	return data, nil
+}
+
+// do sends a request with the body encoded as JSON, if it is not nil, and
+// decodes the response into resp, if it is not nil
+func (c *Client) do(ctx context.Context, method, path string, body, resp interface{}) error {
+	var payload io.Reader
+	contentType := ""
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload, contentType = bytes.NewReader(data), "application/json"
+	}
+
+	data, err := c.send(ctx, method, path, contentType, payload)
+	if err != nil || resp == nil {
+		return err
+	}
+	return json.Unmarshal(data, resp)
+}
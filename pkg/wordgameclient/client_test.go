@@ -0,0 +1,308 @@
+package wordgameclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fantashley/wordgame-controller/pkg/wordgameserver"
+	"github.com/google/uuid"
+)
+
+// schemaTypes is the client type for each schema in the OpenAPI document
+var schemaTypes = map[string]interface{}{
+	"GeneralGameRequest":  GeneralGameRequest{},
+	"GameRules":           GameRules{},
+	"SquareCoordinate":    SquareCoordinate{},
+	"GridTile":            GridTile{},
+	"GamePlayRequest":     GamePlayRequest{},
+	"Tile":                Tile{},
+	"Square":              Square{},
+	"ScrabbleBoard":       ScrabbleBoard{},
+	"Player":              Player{},
+	"TeamScore":           TeamScore{},
+	"GameStateResponse":   GameStateResponse{},
+	"InboxRequest":        InboxRequest{},
+	"InboxEntry":          InboxEntry{},
+	"UnseenTilesResponse": UnseenTilesResponse{},
+	"TileDraw":            TileDraw{},
+	"GameEvent":           GameEvent{},
+	"ChatRequest":         ChatRequest{},
+	"ChatMessage":         ChatMessage{},
+	"LobbyGame":           LobbyGame{},
+	"MatchRequest":        MatchRequest{},
+	"Move":                Move{},
+	"PuzzleRequest":       PuzzleRequest{},
+	"Puzzle":              Puzzle{},
+	"PuzzleGrade":         PuzzleGrade{},
+	"WordLookupRequest":   WordLookupRequest{},
+	"WordLookupResponse":  WordLookupResponse{},
+	"StudyRequest":        StudyRequest{},
+	"StudyQuestion":       StudyQuestion{},
+	"StudyResult":         StudyResult{},
+	"CardboxSummary":      CardboxSummary{},
+	"SpectatorUpdate":     SpectatorUpdate{},
+	"ErrorResponse":       ErrorResponse{},
+	"ErrorDetail":         ErrorDetail{},
+	"GameResource":        GameResource{},
+}
+
+// TestTypesMatchSchemas checks that each type has the properties of its schema
+// in the document served by the server
+func TestTypesMatchSchemas(t *testing.T) {
+	srv := httptest.NewServer(wordgameserver.Handler())
+	defer srv.Close()
+
+	r, err := http.Get(srv.URL + "/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Body.Close()
+
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, schema := range doc.Components.Schemas {
+		v, ok := schemaTypes[name]
+		if !ok {
+			t.Errorf("Schema %s has no type", name)
+			continue
+		}
+
+		typ := reflect.TypeOf(v)
+		if typ.Kind() != reflect.Struct {
+			continue
+		}
+		fields := jsonNames(typ)
+		for prop := range schema.Properties {
+			if !fields[prop] {
+				t.Errorf("%s has no field for %s", name, prop)
+			}
+		}
+		for field := range fields {
+			if _, ok := schema.Properties[field]; !ok {
+				t.Errorf("%s has field %s, which is not in the schema", name, field)
+			}
+		}
+	}
+}
+
+// jsonNames returns the JSON names of a struct's fields, flattening untagged
+// embedded structs
+func jsonNames(typ reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.Anonymous && name == "" {
+			for n := range jsonNames(f.Type) {
+				names[n] = true
+			}
+			continue
+		}
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+func TestClientGame(t *testing.T) {
+	srv := httptest.NewServer(wordgameserver.Handler())
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c := New(srv.URL + "/")
+
+	ann, ben := "Ann", "Ben"
+	created, err := c.CreateGame(ctx, GeneralGameRequest{PlayerName: &ann})
+	if err != nil {
+		t.Fatal(err)
+	} else if created.PlayerID == nil {
+		t.Fatal("Creator was not added to the game")
+	}
+	gameID := created.GameID
+
+	joined, err := c.JoinGame(ctx, GeneralGameRequest{GameID: gameID, PlayerName: &ben})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	open, err := c.ListOpenGames(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, g := range open {
+		found = found || (g.GameID == gameID && reflect.DeepEqual(g.Players, []string{"Ann", "Ben"}))
+	}
+	if !found {
+		t.Errorf("Open games %+v do not include the game", open)
+	}
+
+	events := make(chan Event, 8)
+	go c.WatchGame(ctx, gameID, nil, events)
+
+	err = c.StartGame(ctx, GeneralGameRequest{GameID: gameID, PlayerID: joined.PlayerID})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden || apiErr.Code != "NOT_HOST" {
+		t.Errorf("Expected a forbidden error when a guest starts the game, got %v", err)
+	}
+	if err := c.StartGame(ctx, GeneralGameRequest{GameID: gameID, PlayerID: created.PlayerID}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case e := <-events:
+		var u SpectatorUpdate
+		if e.Name != "state" {
+			t.Errorf("First event is %q, expected state", e.Name)
+		} else if err := json.Unmarshal(e.Data, &u); err != nil || u.GameID != gameID {
+			t.Errorf("Update %s is not for the game: %v", e.Data, err)
+		}
+	case <-ctx.Done():
+		t.Fatal("No update after the game started")
+	}
+
+	// Whoever is first plays their first two tiles from the star
+	playerIDs := map[string]uuid.UUID{"Ann": *created.PlayerID, "Ben": *joined.PlayerID}
+	state, err := c.GetGameState(ctx, GeneralGameRequest{GameID: gameID, PlayerID: created.PlayerID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := playerIDs[state.Players[state.PlayerTurn].Name]
+
+	state, err = c.GetGameState(ctx, GeneralGameRequest{GameID: gameID, PlayerID: &first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	play := GamePlayRequest{
+		GameID:   gameID,
+		PlayerID: first,
+		StartPos: SquareCoordinate{Row: 7, Col: 7},
+		EndPos:   SquareCoordinate{Row: 7, Col: 8},
+		Tiles:    state.PlayerTiles[:2],
+	}
+	for _, l := range play.Tiles {
+		if l == ' ' {
+			play.Blanks = append(play.Blanks, 'E')
+		}
+	}
+	state, err = c.PlayMove(ctx, play)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Board[7][7].Letter == 0 || state.Board[7][8].Letter == 0 {
+		t.Error("Play is not on the board")
+	}
+
+	_, err = c.PlayMove(ctx, GamePlayRequest{GameID: gameID, PlayerID: first, Move: "-"})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict || apiErr.Code != "NOT_YOUR_TURN" {
+		t.Errorf("Expected a not your turn error playing out of turn, got %v", err)
+	}
+
+	history, err := c.GetGameHistory(ctx, GeneralGameRequest{GameID: gameID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := history[len(history)-1]; last.Type != "play" || last.Move == "" {
+		t.Errorf("Last event is %+v, expected the play", last)
+	}
+
+	text, err := c.GetGameStateText(ctx, GeneralGameRequest{GameID: gameID, PlayerID: &first}, false)
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(text, "Tiles in bag:") {
+		t.Errorf("Text state is %q", text)
+	}
+
+	gcg, err := c.ExportGCG(ctx, gameID)
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(gcg, "#player1") {
+		t.Errorf("GCG is %q", gcg)
+	}
+
+	svg, err := c.GetBoardSVG(ctx, gameID, true, true)
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.HasPrefix(string(svg), "<svg") {
+		t.Errorf("SVG starts %q", svg[:10])
+	}
+}
+
+func TestClientV2Game(t *testing.T) {
+	srv := httptest.NewServer(wordgameserver.Handler())
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	c := New(srv.URL)
+
+	ann := "Ann"
+	created, err := c.CreateGameV2(ctx, GeneralGameRequest{PlayerName: &ann})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gameID := created.GameID
+	joined, err := c.AddPlayerV2(ctx, gameID, "Ben")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing waits for the game to start
+	game, err := c.GetGameV2(ctx, gameID, created.PlayerID)
+	if err != nil {
+		t.Fatal(err)
+	} else if game.Started || game.State != nil || !reflect.DeepEqual(game.Players, []string{"Ann", "Ben"}) {
+		t.Errorf("Game before starting is %+v", game)
+	}
+	_, err = c.PlayMoveV2(ctx, gameID, GamePlayRequest{PlayerID: *created.PlayerID, Move: "-"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "GAME_NOT_STARTED" {
+		t.Errorf("Expected a not started error playing before the start, got %v", err)
+	}
+
+	if err := c.StartGameV2(ctx, gameID, *created.PlayerID); err != nil {
+		t.Fatal(err)
+	}
+	game, err = c.GetGameV2(ctx, gameID, created.PlayerID)
+	if err != nil {
+		t.Fatal(err)
+	} else if !game.Started || game.State == nil {
+		t.Fatalf("Game after starting is %+v", game)
+	}
+
+	playerIDs := map[string]uuid.UUID{"Ann": *created.PlayerID, "Ben": *joined.PlayerID}
+	first := playerIDs[game.State.Players[game.State.PlayerTurn].Name]
+	if _, err := c.PlayMoveV2(ctx, gameID, GamePlayRequest{PlayerID: first, Move: "-"}); err != nil {
+		t.Fatal(err)
+	}
+
+	moves, err := c.ListMovesV2(ctx, gameID, nil)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(moves) != 1 || moves[0].Type != "pass" {
+		t.Errorf("Moves are %+v, expected the pass", moves)
+	}
+}
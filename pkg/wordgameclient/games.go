@@ -0,0 +1,233 @@
+package wordgameclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CreateGame creates a game with the request's rules. If the request has a
+// player name, that player joins as the host and their ID is returned.
+func (c *Client) CreateGame(ctx context.Context, req GeneralGameRequest) (GeneralGameRequest, error) {
+	var resp GeneralGameRequest
+	err := c.do(ctx, http.MethodPost, "/game/create", req, &resp)
+	return resp, err
+}
+
+// JoinGame adds the named player to the game and returns their ID
+func (c *Client) JoinGame(ctx context.Context, req GeneralGameRequest) (GeneralGameRequest, error) {
+	var resp GeneralGameRequest
+	err := c.do(ctx, http.MethodPost, "/game/join", req, &resp)
+	return resp, err
+}
+
+// StartGame starts the game. Only the host can start it.
+func (c *Client) StartGame(ctx context.Context, req GeneralGameRequest) error {
+	return c.do(ctx, http.MethodPost, "/game/start", req, nil)
+}
+
+// LeaveGame removes the player from a game that has not started
+func (c *Client) LeaveGame(ctx context.Context, req GeneralGameRequest) error {
+	return c.do(ctx, http.MethodPost, "/game/leave", req, nil)
+}
+
+// KickPlayer removes the target player from a game that has not started. Only
+// the host can remove players.
+func (c *Client) KickPlayer(ctx context.Context, req GeneralGameRequest) error {
+	return c.do(ctx, http.MethodPost, "/game/kick", req, nil)
+}
+
+// LockGame locks or unlocks the game to new players. Only the host can lock
+// the game.
+func (c *Client) LockGame(ctx context.Context, req GeneralGameRequest) error {
+	return c.do(ctx, http.MethodPost, "/game/lock", req, nil)
+}
+
+// UpdateSettings replaces the rules of a game that has not started. Only the
+// host can change the rules.
+func (c *Client) UpdateSettings(ctx context.Context, req GeneralGameRequest) error {
+	return c.do(ctx, http.MethodPost, "/game/settings", req, nil)
+}
+
+// GetGameState returns the game as seen by the player. It fails with
+// GAME_NOT_STARTED until the game has started.
+func (c *Client) GetGameState(ctx context.Context, req GeneralGameRequest) (GameStateResponse, error) {
+	var resp GameStateResponse
+	err := c.do(ctx, http.MethodPost, "/game/state", req, &resp)
+	return resp, err
+}
+
+// GetGameStateText returns the game as seen by the player drawn as text,
+// optionally colored with ANSI escape codes
+func (c *Client) GetGameStateText(ctx context.Context, req GeneralGameRequest, color bool) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("format", "text")
+	q.Set("color", strconv.FormatBool(color))
+	data, err := c.send(ctx, http.MethodPost, "/game/state?"+q.Encode(), "application/json", bytes.NewReader(body))
+	return string(data), err
+}
+
+// PlayMove plays, swaps or passes, and returns the game state afterwards
+func (c *Client) PlayMove(ctx context.Context, req GamePlayRequest) (GameStateResponse, error) {
+	var resp GameStateResponse
+	err := c.do(ctx, http.MethodPost, "/game/play", req, &resp)
+	return resp, err
+}
+
+// GetUnseenTiles counts the tiles the player has not seen
+func (c *Client) GetUnseenTiles(ctx context.Context, req GeneralGameRequest) (UnseenTilesResponse, error) {
+	var resp UnseenTilesResponse
+	err := c.do(ctx, http.MethodPost, "/game/unseen", req, &resp)
+	return resp, err
+}
+
+// GetGameHistory lists everything that has happened in the game
+func (c *Client) GetGameHistory(ctx context.Context, req GeneralGameRequest) ([]GameEvent, error) {
+	var resp []GameEvent
+	err := c.do(ctx, http.MethodPost, "/game/history", req, &resp)
+	return resp, err
+}
+
+// ExportGCG returns the game record in GCG format
+func (c *Client) ExportGCG(ctx context.Context, gameID uuid.UUID) (string, error) {
+	data, err := c.send(ctx, http.MethodGet, "/game/gcg?"+gameQuery(gameID).Encode(), "", nil)
+	return string(data), err
+}
+
+// ImportGCG loads a GCG file into a new game for review and returns its ID
+func (c *Client) ImportGCG(ctx context.Context, gcg io.Reader) (GeneralGameRequest, error) {
+	var resp GeneralGameRequest
+	data, err := c.send(ctx, http.MethodPost, "/game/import", "text/plain; charset=UTF-8", gcg)
+	if err != nil {
+		return resp, err
+	}
+	err = json.Unmarshal(data, &resp)
+	return resp, err
+}
+
+// GetBoardSVG draws the board as SVG, optionally highlighting the last play
+// and showing the scores
+func (c *Client) GetBoardSVG(ctx context.Context, gameID uuid.UUID, lastPlay, scores bool) ([]byte, error) {
+	return c.boardImage(ctx, "/game/board.svg", gameID, lastPlay, scores)
+}
+
+// GetBoardPNG draws the board as PNG, optionally highlighting the last play
+// and showing the scores
+func (c *Client) GetBoardPNG(ctx context.Context, gameID uuid.UUID, lastPlay, scores bool) ([]byte, error) {
+	return c.boardImage(ctx, "/game/board.png", gameID, lastPlay, scores)
+}
+
+func (c *Client) boardImage(ctx context.Context, path string, gameID uuid.UUID, lastPlay, scores bool) ([]byte, error) {
+	q := gameQuery(gameID)
+	q.Set("last_play", strconv.FormatBool(lastPlay))
+	q.Set("scores", strconv.FormatBool(scores))
+	return c.send(ctx, http.MethodGet, path+"?"+q.Encode(), "", nil)
+}
+
+// WatchGame streams the game's server-sent events until the context is done
+// or the connection is lost. Players may give their ID to receive the chat as
+// players, and spectators give nil.
+func (c *Client) WatchGame(ctx context.Context, gameID uuid.UUID, playerID *uuid.UUID, events chan<- Event) error {
+	q := gameQuery(gameID)
+	if playerID != nil {
+		q.Set("player_id", playerID.String())
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.BaseURL+"/game/watch?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.httpClient().Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return &Error{StatusCode: r.StatusCode, Message: r.Status}
+	}
+
+	var e Event
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			e.Name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.Data = []byte(strings.TrimPrefix(line, "data: "))
+		case line == "" && e.Name != "":
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			e = Event{}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// ReadChat returns the chat messages the player or spectator can see
+func (c *Client) ReadChat(ctx context.Context, req ChatRequest) ([]ChatMessage, error) {
+	var resp []ChatMessage
+	err := c.do(ctx, http.MethodPost, "/game/chat", req, &resp)
+	return resp, err
+}
+
+// PostChat posts a message to the game's chat
+func (c *Client) PostChat(ctx context.Context, req ChatRequest) (ChatMessage, error) {
+	var resp ChatMessage
+	err := c.do(ctx, http.MethodPost, "/game/chat/post", req, &resp)
+	return resp, err
+}
+
+// MuteChat mutes or unmutes the chat for the player
+func (c *Client) MuteChat(ctx context.Context, req ChatRequest) error {
+	return c.do(ctx, http.MethodPost, "/game/chat/mute", req, nil)
+}
+
+// GetInbox lists the games in which any of the players is up next
+func (c *Client) GetInbox(ctx context.Context, req InboxRequest) ([]InboxEntry, error) {
+	var resp []InboxEntry
+	err := c.do(ctx, http.MethodPost, "/player/inbox", req, &resp)
+	return resp, err
+}
+
+// ListOpenGames lists the games waiting for players
+func (c *Client) ListOpenGames(ctx context.Context) ([]LobbyGame, error) {
+	var resp []LobbyGame
+	err := c.do(ctx, http.MethodGet, "/lobby/games", nil, &resp)
+	return resp, err
+}
+
+// MatchPlayer waits to be matched into a game, and returns the game and the
+// player's ID once it starts
+func (c *Client) MatchPlayer(ctx context.Context, req MatchRequest) (GeneralGameRequest, error) {
+	var resp GeneralGameRequest
+	err := c.do(ctx, http.MethodPost, "/lobby/match", req, &resp)
+	return resp, err
+}
+
+// gameQuery returns query parameters naming the game
+func gameQuery(gameID uuid.UUID) url.Values {
+	q := url.Values{}
+	q.Set("game_id", gameID.String())
+	return q
+}
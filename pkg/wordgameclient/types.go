@@ -0,0 +1,343 @@
+package wordgameclient
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The types below match the schemas of the same names in the server's OpenAPI
+// document. Fields holding []byte are sent as base64, as the API expects.
+
+// GeneralGameRequest is the catch-all request and response for requests that
+// don't need special fields
+type GeneralGameRequest struct {
+	GameID     uuid.UUID  `json:"game_id"`
+	PlayerID   *uuid.UUID `json:"player_id,omitempty"`
+	PlayerName *string    `json:"player_name,omitempty"`
+	Rules      *GameRules `json:"rules,omitempty"`
+	TargetID   *uuid.UUID `json:"target_id,omitempty"` // player acted on by the host
+	Locked     *bool      `json:"locked,omitempty"`
+	Team       *int       `json:"team,omitempty"` // team chosen when joining a team game, any team with room if not set
+}
+
+// GameRules are the rules chosen when a game is created
+type GameRules struct {
+	Dictionary          string `json:"dictionary,omitempty"`            // name of the word list plays are checked against, none if not set
+	Clabbers            bool   `json:"clabbers,omitempty"`              // accept any anagram of a dictionary word
+	TurnOrder           string `json:"turn_order,omitempty"`            // join, random or draw, join order if not set
+	DisableTileTracking bool   `json:"disable_tile_tracking,omitempty"` // hide unseen tiles, as in strict tournament play
+	ClockMinutes        int    `json:"clock_minutes,omitempty"`         // time each player has for the whole game, zero for untimed
+	ForfeitOnTime       bool   `json:"forfeit_on_time,omitempty"`       // forfeit players who run out of time instead of penalizing them
+	TurnDeadlineHours   int    `json:"turn_deadline_hours,omitempty"`   // hours each turn may take in correspondence games, zero for none
+	ForfeitOnDeadline   bool   `json:"forfeit_on_deadline,omitempty"`   // forfeit players who miss a deadline instead of passing their turn
+
+	SpectatorRackDelaySeconds int `json:"spectator_rack_delay_seconds,omitempty"` // delay before spectators see racks, zero to never show them
+
+	Duplicate             bool `json:"duplicate,omitempty"`               // every player plays the same rack each round and scores their own move
+	DuplicateRoundSeconds int  `json:"duplicate_round_seconds,omitempty"` // time to submit a move each duplicate round, zero for no limit
+
+	Speed bool `json:"speed,omitempty"` // every player builds a private grid at once, peeling from a shared bag
+
+	Teams           bool `json:"teams,omitempty"`             // two teams of two sharing a score
+	TeamRackSharing bool `json:"team_rack_sharing,omitempty"` // let teammates see each other's racks
+
+	SpectatorChat bool     `json:"spectator_chat,omitempty"` // let spectators read and post to the chat
+	ChatFilter    []string `json:"chat_filter,omitempty"`    // words masked out of chat messages
+}
+
+// SquareCoordinate is a square on the board, counted from 0 at the top left
+type SquareCoordinate struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// GridTile is a tile on a player's private grid in the speed variant
+type GridTile struct {
+	Row    int    `json:"row"`
+	Col    int    `json:"col"`
+	Letter string `json:"letter"`          // letter on the tile, a space for a blank
+	Blank  string `json:"blank,omitempty"` // letter a blank stands for
+}
+
+// GamePlayRequest is a play, swap or pass. A play may be given by its
+// positions and tiles or by Move in standard notation.
+type GamePlayRequest struct {
+	GameID   uuid.UUID        `json:"game_id"`
+	PlayerID uuid.UUID        `json:"player_id"`
+	StartPos SquareCoordinate `json:"start_pos"`
+	EndPos   SquareCoordinate `json:"end_pos"`
+	Tiles    []byte           `json:"tiles"`            // tiles placed in order, a space for a blank
+	Blanks   []byte           `json:"blanks,omitempty"` // letters the blanks stand for, in order
+	Move     string           `json:"move,omitempty"`   // play in standard notation, used instead of positions and tiles
+	Swap     bool             `json:"swap"`
+	Peel     bool             `json:"peel,omitempty"`
+	Grid     []GridTile       `json:"grid,omitempty"`
+}
+
+// Tile is a tile on the board
+type Tile struct {
+	Letter byte `json:"letter"` // the character written on the tile, zero for an empty square
+	Value  int  `json:"value"`  // the point value of playing the tile, zero for a blank
+}
+
+// Square is a square on the board, with the tile on it if there is one
+type Square struct {
+	SquareType string `json:"type"`
+	Tile       `json:"tile,omitempty"`
+}
+
+// ScrabbleBoard is the board as rows of squares
+type ScrabbleBoard [15][15]Square
+
+// Player is a player as other players see them
+type Player struct {
+	Name          string        `json:"name"`
+	Number        int           `json:"number"` // position in the turn order
+	Score         int           `json:"score"`
+	TimeRemaining time.Duration `json:"time_remaining,omitempty"` // time left on the player's clock, negative in overtime
+	Forfeited     bool          `json:"forfeited,omitempty"`
+	Team          int           `json:"team,omitempty"` // team the player plays for, zero if not a team game
+}
+
+// TeamScore is the combined score of a team
+type TeamScore struct {
+	Team    int      `json:"team"`    // team number, starting at 1
+	Players []string `json:"players"` // names of the players on the team
+	Score   int      `json:"score"`
+}
+
+// GameStateResponse is the game as seen by one player
+type GameStateResponse struct {
+	GameID         uuid.UUID     `json:"game_id"`
+	Players        []*Player     `json:"players"`
+	Board          ScrabbleBoard `json:"board"`
+	PlayerTurn     int           `json:"turn"`
+	PlayerTiles    []byte        `json:"tiles"`
+	TilesRemaining int           `json:"tiles_remaining"`
+	Teams          []TeamScore   `json:"teams,omitempty"`
+	TeammateTiles  []byte        `json:"teammate_tiles,omitempty"`
+	Grid           []GridTile    `json:"grid,omitempty"`
+	Winner         string        `json:"winner,omitempty"`
+}
+
+// InboxRequest lists the players to look up games for
+type InboxRequest struct {
+	PlayerIDs []uuid.UUID `json:"player_ids"`
+}
+
+// InboxEntry is a game in which one of the players is up next
+type InboxEntry struct {
+	GameID   uuid.UUID  `json:"game_id"`
+	PlayerID uuid.UUID  `json:"player_id"`
+	Deadline *time.Time `json:"deadline,omitempty"`
+}
+
+// UnseenTilesResponse counts the tiles a player has not seen
+type UnseenTilesResponse struct {
+	GameID     uuid.UUID      `json:"game_id"`
+	Tiles      map[string]int `json:"tiles"`  // unseen count per letter, blanks keyed by " "
+	Total      int            `json:"total"`  // unseen tiles in the bag and opponents' racks
+	InBag      int            `json:"in_bag"` // tiles remaining in the bag
+	Vowels     int            `json:"vowels"`
+	Consonants int            `json:"consonants"`
+	Blanks     int            `json:"blanks"`
+}
+
+// TileDraw is a tile drawn to decide the turn order
+type TileDraw struct {
+	Round  int    `json:"round"`  // draws are repeated between tied players
+	Player string `json:"player"` // name of the player who drew
+	Letter string `json:"letter"` // letter drawn, a space for a blank
+}
+
+// GameEvent is something that happened in a game
+type GameEvent struct {
+	Type   string            `json:"type"`             // such as draw, play, swap, pass, penalty, forfeit, endrack or chat
+	Turn   int               `json:"turn"`             // turn count when the event happened
+	Player string            `json:"player,omitempty"` // name of the player involved, if any
+	Rack   string            `json:"rack,omitempty"`   // player's rack before their turn, hidden from others until the game is over
+	Start  *SquareCoordinate `json:"start,omitempty"`  // first square of a play
+	End    *SquareCoordinate `json:"end,omitempty"`    // last square of a play
+	Tiles  string            `json:"tiles,omitempty"`  // tiles played with blanks lowercase, or tiles swapped
+	Move   string            `json:"move,omitempty"`   // play in standard notation, such as 8H WORD
+	Words  []string          `json:"words,omitempty"`  // words formed by a play, main word first
+	Score  int               `json:"score,omitempty"`  // points scored by a play, or lost to a penalty
+	Count  int               `json:"count,omitempty"`  // number of tiles swapped
+	Draws  []TileDraw        `json:"draws,omitempty"`  // tiles drawn to decide turn order
+	Text   string            `json:"text,omitempty"`   // text of a chat message
+	Time   time.Time         `json:"time"`
+}
+
+// ChatRequest reads, posts to or mutes a game's chat
+type ChatRequest struct {
+	GameID        uuid.UUID  `json:"game_id"`
+	PlayerID      *uuid.UUID `json:"player_id,omitempty"`
+	SpectatorName *string    `json:"spectator_name,omitempty"`
+	Text          string     `json:"text,omitempty"`
+	Since         int        `json:"since,omitempty"` // only return messages with an ID at least this
+	Muted         bool       `json:"muted,omitempty"`
+	TeamOnly      bool       `json:"team_only,omitempty"` // post privately to the sender's team
+}
+
+// ChatMessage is a message in a game's chat
+type ChatMessage struct {
+	ID        int       `json:"id"`                  // position of the message in the chat
+	Sender    string    `json:"sender"`              // display name of the player or spectator
+	Spectator bool      `json:"spectator,omitempty"` // true if posted by a spectator
+	TeamOnly  bool      `json:"team_only,omitempty"` // true if only the sender's team can see it
+	Text      string    `json:"text"`                // filtered message text
+	Time      time.Time `json:"time"`
+}
+
+// LobbyGame is a game waiting for players
+type LobbyGame struct {
+	GeneralGameRequest
+	Players []string `json:"players"` // names of the players who have joined
+	Seats   int      `json:"seats"`   // seats still open
+}
+
+// MatchRequest asks to be matched with other players waiting for the same
+// kind of game
+type MatchRequest struct {
+	PlayerName string    `json:"player_name"`
+	Rules      GameRules `json:"rules"`
+	Skill      int       `json:"skill"`           // player's rating, matched with players rated within 200 of each other
+	Seats      int       `json:"seats,omitempty"` // players per game, two if not set
+}
+
+// Move is a play found by the server's move generator
+type Move struct {
+	StartPos SquareCoordinate `json:"start_pos"`
+	EndPos   SquareCoordinate `json:"end_pos"`
+	Tiles    []byte           `json:"tiles"`
+	Blanks   []byte           `json:"blanks,omitempty"`
+	Words    []string         `json:"words"` // words formed, main word first, blanks lowercase
+	Score    int              `json:"score"`
+}
+
+// PuzzleRequest asks for the daily puzzle, or grades an answer to it
+type PuzzleRequest struct {
+	Date       string           `json:"date,omitempty"` // day of the puzzle as YYYY-MM-DD within a day of today, today if not set
+	Dictionary string           `json:"dictionary"`
+	Answer     *GamePlayRequest `json:"answer,omitempty"`
+}
+
+// Puzzle is a board and rack to find the best play for
+type Puzzle struct {
+	Date       string        `json:"date"`
+	Dictionary string        `json:"dictionary"`
+	Board      ScrabbleBoard `json:"board"`
+	Rack       []byte        `json:"rack"`
+}
+
+// PuzzleGrade is the grade of an answer to a puzzle
+type PuzzleGrade struct {
+	Score     int     `json:"score"`      // score of the answer
+	BestScore int     `json:"best_score"` // score of the top move
+	Rank      int     `json:"rank"`       // position of the answer's score among all plays, 1 for the top
+	Credit    float64 `json:"credit"`     // answer's score as a fraction of the top move's
+	Best      Move    `json:"best"`
+}
+
+// WordLookupRequest looks words up in a dictionary
+type WordLookupRequest struct {
+	Dictionary string `json:"dictionary"`
+	Word       string `json:"word,omitempty"`    // word to check or find hooks for
+	Rack       string `json:"rack,omitempty"`    // letters to anagram, with ? for a blank
+	Build      bool   `json:"build,omitempty"`   // include words using only some of the rack
+	Pattern    string `json:"pattern,omitempty"` // pattern such as ?A?E
+}
+
+// WordLookupResponse is the result of a word lookup
+type WordLookupResponse struct {
+	Dictionary string   `json:"dictionary"`
+	Word       string   `json:"word,omitempty"`
+	Valid      *bool    `json:"valid,omitempty"`
+	Words      []string `json:"words,omitempty"`
+	FrontHooks string   `json:"front_hooks,omitempty"` // letters that can go before the word
+	BackHooks  string   `json:"back_hooks,omitempty"`  // letters that can go after the word
+}
+
+// StudyRequest asks for alphagrams to study, answers one, or summarizes a
+// cardbox
+type StudyRequest struct {
+	User       string   `json:"user"`
+	Dictionary string   `json:"dictionary"`
+	Length     int      `json:"length,omitempty"`    // word length to quiz on
+	Limit      int      `json:"limit,omitempty"`     // quiz the most probable alphagrams up to this many
+	Count      int      `json:"count,omitempty"`     // questions to return
+	Alphagram  string   `json:"alphagram,omitempty"` // question being answered
+	Words      []string `json:"words,omitempty"`     // answer given
+}
+
+// StudyQuestion is an alphagram to find the words for
+type StudyQuestion struct {
+	Alphagram string     `json:"alphagram"`
+	Answers   int        `json:"answers"`       // number of words to find
+	Rank      int        `json:"rank"`          // position in probability order, 1 for the most probable
+	Box       int        `json:"box"`           // cardbox the question is in, -1 if it is new
+	Due       *time.Time `json:"due,omitempty"` // when a card already in the cardbox came due
+}
+
+// StudyResult is the result of answering an alphagram
+type StudyResult struct {
+	Alphagram string    `json:"alphagram"`
+	Correct   bool      `json:"correct"`
+	Answers   []string  `json:"answers"` // every valid word
+	Missed    []string  `json:"missed"`  // valid words not given
+	Wrong     []string  `json:"wrong"`   // words given that are not valid
+	Box       int       `json:"box"`
+	Due       time.Time `json:"due"`
+}
+
+// CardboxSummary counts the cards in a user's cardbox
+type CardboxSummary struct {
+	User       string `json:"user"`
+	Dictionary string `json:"dictionary"`
+	Boxes      []int  `json:"boxes"` // cards in each box
+	Due        int    `json:"due"`   // cards due now
+}
+
+// SpectatorUpdate is the game state sent to everyone watching a game
+type SpectatorUpdate struct {
+	GameID         uuid.UUID      `json:"game_id"`
+	Players        []*Player      `json:"players"`
+	Board          ScrabbleBoard  `json:"board"`
+	PlayerTurn     int            `json:"turn"`
+	TurnCount      int            `json:"turn_count"`
+	TilesRemaining int            `json:"tiles_remaining"`
+	Teams          []TeamScore    `json:"teams,omitempty"`
+	Winner         string         `json:"winner,omitempty"` // set once the game is over
+	Racks          map[int][]byte `json:"racks,omitempty"`  // racks by player number, as they were at TurnCount
+}
+
+// GameResource is a game in the v2 API. State is only included once the game
+// has started, and only for the player whose ID is given.
+type GameResource struct {
+	GameID  uuid.UUID          `json:"game_id"`
+	Rules   GameRules          `json:"rules"`
+	Started bool               `json:"started"`
+	Locked  bool               `json:"locked"`
+	Players []string           `json:"players"`         // names in turn order
+	State   *GameStateResponse `json:"state,omitempty"` // game as seen by the player
+}
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes why a request failed
+type ErrorDetail struct {
+	Code    string `json:"code"`    // stable reason, such as NOT_YOUR_TURN
+	Message string `json:"message"` // reason for people
+}
+
+// Event is a server-sent event from a game being watched. State events hold a
+// SpectatorUpdate and chat events hold a ChatMessage.
+type Event struct {
+	Name string
+	Data json.RawMessage
+}
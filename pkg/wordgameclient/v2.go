@@ -0,0 +1,71 @@
+package wordgameclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// The v2 API addresses games by their path, and none of its requests wait for
+// a game to start.
+
+// CreateGameV2 creates a game with the request's rules. If the request has a
+// player name, that player joins as the host and their ID is returned.
+func (c *Client) CreateGameV2(ctx context.Context, req GeneralGameRequest) (GeneralGameRequest, error) {
+	var resp GeneralGameRequest
+	err := c.do(ctx, http.MethodPost, "/v2/games", req, &resp)
+	return resp, err
+}
+
+// GetGameV2 describes the game. If a player ID is given, the game as seen by
+// that player is included once the game has started.
+func (c *Client) GetGameV2(ctx context.Context, gameID uuid.UUID, playerID *uuid.UUID) (GameResource, error) {
+	var resp GameResource
+	err := c.do(ctx, http.MethodGet, withPlayer(gamePath(gameID), playerID), nil, &resp)
+	return resp, err
+}
+
+// AddPlayerV2 adds the named player to the game and returns their ID
+func (c *Client) AddPlayerV2(ctx context.Context, gameID uuid.UUID, name string) (GeneralGameRequest, error) {
+	var resp GeneralGameRequest
+	err := c.do(ctx, http.MethodPost, gamePath(gameID)+"/players", GeneralGameRequest{PlayerName: &name}, &resp)
+	return resp, err
+}
+
+// StartGameV2 starts the game. Only the host can start it.
+func (c *Client) StartGameV2(ctx context.Context, gameID, hostID uuid.UUID) error {
+	return c.do(ctx, http.MethodPost, gamePath(gameID)+"/start", GeneralGameRequest{PlayerID: &hostID}, nil)
+}
+
+// PlayMoveV2 plays, swaps or passes in the game, and returns the game state
+// afterwards. The request's game ID is ignored.
+func (c *Client) PlayMoveV2(ctx context.Context, gameID uuid.UUID, req GamePlayRequest) (GameStateResponse, error) {
+	var resp GameStateResponse
+	err := c.do(ctx, http.MethodPost, gamePath(gameID)+"/moves", req, &resp)
+	return resp, err
+}
+
+// ListMovesV2 lists the moves made in the game so far, in order. If a player
+// ID is given, that player's racks are included before the game is over.
+func (c *Client) ListMovesV2(ctx context.Context, gameID uuid.UUID, playerID *uuid.UUID) ([]GameEvent, error) {
+	var resp []GameEvent
+	err := c.do(ctx, http.MethodGet, withPlayer(gamePath(gameID)+"/moves", playerID), nil, &resp)
+	return resp, err
+}
+
+// gamePath returns the v2 path of the game
+func gamePath(gameID uuid.UUID) string {
+	return "/v2/games/" + gameID.String()
+}
+
+// withPlayer adds the player to the path's query, if one is given
+func withPlayer(path string, playerID *uuid.UUID) string {
+	if playerID == nil {
+		return path
+	}
+	q := url.Values{}
+	q.Set("player_id", playerID.String())
+	return path + "?" + q.Encode()
+}
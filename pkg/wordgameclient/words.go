@@ -0,0 +1,68 @@
+package wordgameclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetDailyPuzzle returns the puzzle for the request's date and dictionary
+func (c *Client) GetDailyPuzzle(ctx context.Context, req PuzzleRequest) (Puzzle, error) {
+	var resp Puzzle
+	err := c.do(ctx, http.MethodPost, "/puzzle/daily", req, &resp)
+	return resp, err
+}
+
+// AnswerPuzzle grades the request's answer to the daily puzzle
+func (c *Client) AnswerPuzzle(ctx context.Context, req PuzzleRequest) (PuzzleGrade, error) {
+	var resp PuzzleGrade
+	err := c.do(ctx, http.MethodPost, "/puzzle/answer", req, &resp)
+	return resp, err
+}
+
+// CheckWord reports whether the request's word is in the dictionary
+func (c *Client) CheckWord(ctx context.Context, req WordLookupRequest) (WordLookupResponse, error) {
+	return c.lookup(ctx, "/words/check", req)
+}
+
+// FindAnagrams lists the words the request's rack can make
+func (c *Client) FindAnagrams(ctx context.Context, req WordLookupRequest) (WordLookupResponse, error) {
+	return c.lookup(ctx, "/words/anagrams", req)
+}
+
+// MatchPattern lists the words matching the request's pattern
+func (c *Client) MatchPattern(ctx context.Context, req WordLookupRequest) (WordLookupResponse, error) {
+	return c.lookup(ctx, "/words/pattern", req)
+}
+
+// FindHooks returns the letters that hook onto the request's word
+func (c *Client) FindHooks(ctx context.Context, req WordLookupRequest) (WordLookupResponse, error) {
+	return c.lookup(ctx, "/words/hooks", req)
+}
+
+func (c *Client) lookup(ctx context.Context, path string, req WordLookupRequest) (WordLookupResponse, error) {
+	var resp WordLookupResponse
+	err := c.do(ctx, http.MethodPost, path, req, &resp)
+	return resp, err
+}
+
+// GetStudyQuiz returns alphagrams for the user to study, due cards first
+func (c *Client) GetStudyQuiz(ctx context.Context, req StudyRequest) ([]StudyQuestion, error) {
+	var resp []StudyQuestion
+	err := c.do(ctx, http.MethodPost, "/study/quiz", req, &resp)
+	return resp, err
+}
+
+// AnswerStudyQuestion grades the user's answer to an alphagram and files it
+// in their cardbox
+func (c *Client) AnswerStudyQuestion(ctx context.Context, req StudyRequest) (StudyResult, error) {
+	var resp StudyResult
+	err := c.do(ctx, http.MethodPost, "/study/answer", req, &resp)
+	return resp, err
+}
+
+// GetCardbox summarizes the user's cardbox
+func (c *Client) GetCardbox(ctx context.Context, req StudyRequest) (CardboxSummary, error) {
+	var resp CardboxSummary
+	err := c.do(ctx, http.MethodPost, "/study/cardbox", req, &resp)
+	return resp, err
+}
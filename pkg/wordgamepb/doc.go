@@ -0,0 +1,12 @@
+// Package wordgamepb holds the protobuf messages and gRPC service of the word
+// game server, generated from wordgame.proto.
+//
+// The generators are pinned so that regenerating only changes what the proto
+// file changes: protoc 3.19.1, and the plugin versions matching the protobuf
+// and gRPC modules in go.mod. Run go generate with protoc 3.19.1 on the PATH.
+package wordgamepb
+
+//go:generate sh -c "protoc --version | grep -qx 'libprotoc 3.19.1' || { echo 'protoc 3.19.1 is required' >&2; exit 1; }"
+//go:generate go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.27.1
+//go:generate go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.1.0
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative wordgame.proto
@@ -0,0 +1,1585 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.1
+// source: wordgame.proto
+
+package wordgamepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Rules are the options chosen when a game is created.
+type Rules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dictionary                string   `protobuf:"bytes,1,opt,name=dictionary,proto3" json:"dictionary,omitempty"`                                                 // word list plays are checked against, none if empty
+	Clabbers                  bool     `protobuf:"varint,2,opt,name=clabbers,proto3" json:"clabbers,omitempty"`                                                    // accept any anagram of a dictionary word
+	TurnOrder                 string   `protobuf:"bytes,3,opt,name=turn_order,json=turnOrder,proto3" json:"turn_order,omitempty"`                                  // join, random or draw, join order if empty
+	DisableTileTracking       bool     `protobuf:"varint,4,opt,name=disable_tile_tracking,json=disableTileTracking,proto3" json:"disable_tile_tracking,omitempty"` // hide unseen tiles
+	ClockMinutes              int32    `protobuf:"varint,5,opt,name=clock_minutes,json=clockMinutes,proto3" json:"clock_minutes,omitempty"`                        // time each player has for the game, zero for untimed
+	ForfeitOnTime             bool     `protobuf:"varint,6,opt,name=forfeit_on_time,json=forfeitOnTime,proto3" json:"forfeit_on_time,omitempty"`                   // forfeit players who run out of time
+	TurnDeadlineHours         int32    `protobuf:"varint,7,opt,name=turn_deadline_hours,json=turnDeadlineHours,proto3" json:"turn_deadline_hours,omitempty"`       // hours each turn may take, zero for none
+	ForfeitOnDeadline         bool     `protobuf:"varint,8,opt,name=forfeit_on_deadline,json=forfeitOnDeadline,proto3" json:"forfeit_on_deadline,omitempty"`       // forfeit players who miss a deadline
+	SpectatorRackDelaySeconds int32    `protobuf:"varint,9,opt,name=spectator_rack_delay_seconds,json=spectatorRackDelaySeconds,proto3" json:"spectator_rack_delay_seconds,omitempty"`
+	Duplicate                 bool     `protobuf:"varint,10,opt,name=duplicate,proto3" json:"duplicate,omitempty"` // every player plays the same rack each round
+	DuplicateRoundSeconds     int32    `protobuf:"varint,11,opt,name=duplicate_round_seconds,json=duplicateRoundSeconds,proto3" json:"duplicate_round_seconds,omitempty"`
+	Speed                     bool     `protobuf:"varint,12,opt,name=speed,proto3" json:"speed,omitempty"`                                              // every player builds a private grid at once
+	Teams                     bool     `protobuf:"varint,13,opt,name=teams,proto3" json:"teams,omitempty"`                                              // two teams of two sharing a score
+	TeamRackSharing           bool     `protobuf:"varint,14,opt,name=team_rack_sharing,json=teamRackSharing,proto3" json:"team_rack_sharing,omitempty"` // let teammates see each other's racks
+	SpectatorChat             bool     `protobuf:"varint,15,opt,name=spectator_chat,json=spectatorChat,proto3" json:"spectator_chat,omitempty"`         // let spectators read and post to the chat
+	ChatFilter                []string `protobuf:"bytes,16,rep,name=chat_filter,json=chatFilter,proto3" json:"chat_filter,omitempty"`                   // words masked out of chat messages
+}
+
+func (x *Rules) Reset() {
+	*x = Rules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rules) ProtoMessage() {}
+
+func (x *Rules) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rules.ProtoReflect.Descriptor instead.
+func (*Rules) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Rules) GetDictionary() string {
+	if x != nil {
+		return x.Dictionary
+	}
+	return ""
+}
+
+func (x *Rules) GetClabbers() bool {
+	if x != nil {
+		return x.Clabbers
+	}
+	return false
+}
+
+func (x *Rules) GetTurnOrder() string {
+	if x != nil {
+		return x.TurnOrder
+	}
+	return ""
+}
+
+func (x *Rules) GetDisableTileTracking() bool {
+	if x != nil {
+		return x.DisableTileTracking
+	}
+	return false
+}
+
+func (x *Rules) GetClockMinutes() int32 {
+	if x != nil {
+		return x.ClockMinutes
+	}
+	return 0
+}
+
+func (x *Rules) GetForfeitOnTime() bool {
+	if x != nil {
+		return x.ForfeitOnTime
+	}
+	return false
+}
+
+func (x *Rules) GetTurnDeadlineHours() int32 {
+	if x != nil {
+		return x.TurnDeadlineHours
+	}
+	return 0
+}
+
+func (x *Rules) GetForfeitOnDeadline() bool {
+	if x != nil {
+		return x.ForfeitOnDeadline
+	}
+	return false
+}
+
+func (x *Rules) GetSpectatorRackDelaySeconds() int32 {
+	if x != nil {
+		return x.SpectatorRackDelaySeconds
+	}
+	return 0
+}
+
+func (x *Rules) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *Rules) GetDuplicateRoundSeconds() int32 {
+	if x != nil {
+		return x.DuplicateRoundSeconds
+	}
+	return 0
+}
+
+func (x *Rules) GetSpeed() bool {
+	if x != nil {
+		return x.Speed
+	}
+	return false
+}
+
+func (x *Rules) GetTeams() bool {
+	if x != nil {
+		return x.Teams
+	}
+	return false
+}
+
+func (x *Rules) GetTeamRackSharing() bool {
+	if x != nil {
+		return x.TeamRackSharing
+	}
+	return false
+}
+
+func (x *Rules) GetSpectatorChat() bool {
+	if x != nil {
+		return x.SpectatorChat
+	}
+	return false
+}
+
+func (x *Rules) GetChatFilter() []string {
+	if x != nil {
+		return x.ChatFilter
+	}
+	return nil
+}
+
+// Player is a player as everyone in the game sees them.
+type Player struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name          string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Number        int32                `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"` // position in the turn order, from zero
+	Score         int32                `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	TimeRemaining *durationpb.Duration `protobuf:"bytes,4,opt,name=time_remaining,json=timeRemaining,proto3" json:"time_remaining,omitempty"` // negative in overtime, unset if untimed
+	Forfeited     bool                 `protobuf:"varint,5,opt,name=forfeited,proto3" json:"forfeited,omitempty"`
+	Team          int32                `protobuf:"varint,6,opt,name=team,proto3" json:"team,omitempty"` // zero if not a team game
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Player) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Player) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Player) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Player) GetTimeRemaining() *durationpb.Duration {
+	if x != nil {
+		return x.TimeRemaining
+	}
+	return nil
+}
+
+func (x *Player) GetForfeited() bool {
+	if x != nil {
+		return x.Forfeited
+	}
+	return false
+}
+
+func (x *Player) GetTeam() int32 {
+	if x != nil {
+		return x.Team
+	}
+	return 0
+}
+
+// Square is one square of the board.
+type Square struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type   string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`     // plain, doubleLetter, tripleLetter, doubleWord, tripleWord or star
+	Letter string `protobuf:"bytes,2,opt,name=letter,proto3" json:"letter,omitempty"` // letter of the tile on the square, empty if there is none
+	Value  int32  `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`  // points for the tile, zero for a blank
+}
+
+func (x *Square) Reset() {
+	*x = Square{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Square) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Square) ProtoMessage() {}
+
+func (x *Square) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Square.ProtoReflect.Descriptor instead.
+func (*Square) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Square) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Square) GetLetter() string {
+	if x != nil {
+		return x.Letter
+	}
+	return ""
+}
+
+func (x *Square) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Board is the 15 by 15 grid of squares.
+type Board struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Squares []*Square `protobuf:"bytes,1,rep,name=squares,proto3" json:"squares,omitempty"` // row by row, starting from the top left
+}
+
+func (x *Board) Reset() {
+	*x = Board{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Board) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Board) ProtoMessage() {}
+
+func (x *Board) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Board.ProtoReflect.Descriptor instead.
+func (*Board) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Board) GetSquares() []*Square {
+	if x != nil {
+		return x.Squares
+	}
+	return nil
+}
+
+// Coordinate is a square on the board, counted from zero.
+type Coordinate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Row int32 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Col int32 `protobuf:"varint,2,opt,name=col,proto3" json:"col,omitempty"`
+}
+
+func (x *Coordinate) Reset() {
+	*x = Coordinate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Coordinate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinate) ProtoMessage() {}
+
+func (x *Coordinate) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinate.ProtoReflect.Descriptor instead.
+func (*Coordinate) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Coordinate) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *Coordinate) GetCol() int32 {
+	if x != nil {
+		return x.Col
+	}
+	return 0
+}
+
+// Move is a play, swap or pass. It is given either in standard notation or as
+// the squares and tiles of a play.
+type Move struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Notation string      `protobuf:"bytes,1,opt,name=notation,proto3" json:"notation,omitempty"` // such as "8H WORD", "-ABC" to swap, or "-" to pass
+	Start    *Coordinate `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End      *Coordinate `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	Tiles    string      `protobuf:"bytes,4,opt,name=tiles,proto3" json:"tiles,omitempty"`   // tiles played or swapped, a space for a blank
+	Blanks   string      `protobuf:"bytes,5,opt,name=blanks,proto3" json:"blanks,omitempty"` // letters the blanks stand for, in order
+	Swap     bool        `protobuf:"varint,6,opt,name=swap,proto3" json:"swap,omitempty"`
+}
+
+func (x *Move) Reset() {
+	*x = Move{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Move) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Move) ProtoMessage() {}
+
+func (x *Move) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Move.ProtoReflect.Descriptor instead.
+func (*Move) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Move) GetNotation() string {
+	if x != nil {
+		return x.Notation
+	}
+	return ""
+}
+
+func (x *Move) GetStart() *Coordinate {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Move) GetEnd() *Coordinate {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *Move) GetTiles() string {
+	if x != nil {
+		return x.Tiles
+	}
+	return ""
+}
+
+func (x *Move) GetBlanks() string {
+	if x != nil {
+		return x.Blanks
+	}
+	return ""
+}
+
+func (x *Move) GetSwap() bool {
+	if x != nil {
+		return x.Swap
+	}
+	return false
+}
+
+// Game is the state of a game as seen by a player or a spectator.
+type Game struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId         string    `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Rules          *Rules    `protobuf:"bytes,2,opt,name=rules,proto3" json:"rules,omitempty"`
+	Started        bool      `protobuf:"varint,3,opt,name=started,proto3" json:"started,omitempty"`
+	Locked         bool      `protobuf:"varint,4,opt,name=locked,proto3" json:"locked,omitempty"`
+	Players        []*Player `protobuf:"bytes,5,rep,name=players,proto3" json:"players,omitempty"` // in turn order
+	Board          *Board    `protobuf:"bytes,6,opt,name=board,proto3" json:"board,omitempty"`     // unset until the game starts
+	Turn           int32     `protobuf:"varint,7,opt,name=turn,proto3" json:"turn,omitempty"`      // number of the player up next
+	TilesRemaining int32     `protobuf:"varint,8,opt,name=tiles_remaining,json=tilesRemaining,proto3" json:"tiles_remaining,omitempty"`
+	Rack           string    `protobuf:"bytes,9,opt,name=rack,proto3" json:"rack,omitempty"`                                      // the player's tiles, empty for spectators
+	Winner         string    `protobuf:"bytes,10,opt,name=winner,proto3" json:"winner,omitempty"`                                 // name of the winner, once the game is over
+	TeammateRack   string    `protobuf:"bytes,11,opt,name=teammate_rack,json=teammateRack,proto3" json:"teammate_rack,omitempty"` // teammate's tiles, if the rules share racks
+}
+
+func (x *Game) Reset() {
+	*x = Game{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Game) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Game) ProtoMessage() {}
+
+func (x *Game) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Game.ProtoReflect.Descriptor instead.
+func (*Game) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Game) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *Game) GetRules() *Rules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Game) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+func (x *Game) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *Game) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *Game) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *Game) GetTurn() int32 {
+	if x != nil {
+		return x.Turn
+	}
+	return 0
+}
+
+func (x *Game) GetTilesRemaining() int32 {
+	if x != nil {
+		return x.TilesRemaining
+	}
+	return 0
+}
+
+func (x *Game) GetRack() string {
+	if x != nil {
+		return x.Rack
+	}
+	return ""
+}
+
+func (x *Game) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *Game) GetTeammateRack() string {
+	if x != nil {
+		return x.TeammateRack
+	}
+	return ""
+}
+
+type CreateGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules      *Rules `protobuf:"bytes,1,opt,name=rules,proto3" json:"rules,omitempty"`
+	PlayerName string `protobuf:"bytes,2,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"` // creator's name, to join as the host
+}
+
+func (x *CreateGameRequest) Reset() {
+	*x = CreateGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameRequest) ProtoMessage() {}
+
+func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
+func (*CreateGameRequest) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateGameRequest) GetRules() *Rules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *CreateGameRequest) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+type CreateGameResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId   string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId string `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // host's ID, empty if no name was given
+}
+
+func (x *CreateGameResponse) Reset() {
+	*x = CreateGameResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameResponse) ProtoMessage() {}
+
+func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameResponse.ProtoReflect.Descriptor instead.
+func (*CreateGameResponse) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateGameResponse) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *CreateGameResponse) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type JoinGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId     string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerName string `protobuf:"bytes,2,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+}
+
+func (x *JoinGameRequest) Reset() {
+	*x = JoinGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGameRequest) ProtoMessage() {}
+
+func (x *JoinGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGameRequest.ProtoReflect.Descriptor instead.
+func (*JoinGameRequest) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JoinGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *JoinGameRequest) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+type JoinGameResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId string `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+}
+
+func (x *JoinGameResponse) Reset() {
+	*x = JoinGameResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinGameResponse) ProtoMessage() {}
+
+func (x *JoinGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinGameResponse.ProtoReflect.Descriptor instead.
+func (*JoinGameResponse) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *JoinGameResponse) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type StartGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId   string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId string `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // host's ID
+}
+
+func (x *StartGameRequest) Reset() {
+	*x = StartGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartGameRequest) ProtoMessage() {}
+
+func (x *StartGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartGameRequest.ProtoReflect.Descriptor instead.
+func (*StartGameRequest) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StartGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *StartGameRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type StartGameResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StartGameResponse) Reset() {
+	*x = StartGameResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartGameResponse) ProtoMessage() {}
+
+func (x *StartGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartGameResponse.ProtoReflect.Descriptor instead.
+func (*StartGameResponse) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{12}
+}
+
+type GetGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId   string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId string `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // empty for spectators
+}
+
+func (x *GetGameRequest) Reset() {
+	*x = GetGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameRequest) ProtoMessage() {}
+
+func (x *GetGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameRequest.ProtoReflect.Descriptor instead.
+func (*GetGameRequest) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *GetGameRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type PlayRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId   string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId string `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Move     *Move  `protobuf:"bytes,3,opt,name=move,proto3" json:"move,omitempty"`
+}
+
+func (x *PlayRequest) Reset() {
+	*x = PlayRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayRequest) ProtoMessage() {}
+
+func (x *PlayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayRequest.ProtoReflect.Descriptor instead.
+func (*PlayRequest) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PlayRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *PlayRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayRequest) GetMove() *Move {
+	if x != nil {
+		return x.Move
+	}
+	return nil
+}
+
+type WatchGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GameId string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+}
+
+func (x *WatchGameRequest) Reset() {
+	*x = WatchGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wordgame_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchGameRequest) ProtoMessage() {}
+
+func (x *WatchGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wordgame_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchGameRequest.ProtoReflect.Descriptor instead.
+func (*WatchGameRequest) Descriptor() ([]byte, []int) {
+	return file_wordgame_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+var File_wordgame_proto protoreflect.FileDescriptor
+
+var file_wordgame_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x08, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfa, 0x04, 0x0a, 0x05, 0x52,
+	0x75, 0x6c, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6c, 0x61, 0x62, 0x62, 0x65, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x6c, 0x61, 0x62, 0x62, 0x65, 0x72, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x75, 0x72, 0x6e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12,
+	0x32, 0x0a, 0x15, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6c, 0x65, 0x5f,
+	0x74, 0x72, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x69, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6d, 0x69, 0x6e,
+	0x75, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x66,
+	0x65, 0x69, 0x74, 0x5f, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x66, 0x65, 0x69, 0x74, 0x4f, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x2e, 0x0a, 0x13, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e,
+	0x65, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x74,
+	0x75, 0x72, 0x6e, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x48, 0x6f, 0x75, 0x72, 0x73,
+	0x12, 0x2e, 0x0a, 0x13, 0x66, 0x6f, 0x72, 0x66, 0x65, 0x69, 0x74, 0x5f, 0x6f, 0x6e, 0x5f, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x66,
+	0x6f, 0x72, 0x66, 0x65, 0x69, 0x74, 0x4f, 0x6e, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x3f, 0x0a, 0x1c, 0x73, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x72, 0x61,
+	0x63, 0x6b, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x19, 0x73, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x61, 0x63, 0x6b, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12,
+	0x36, 0x0a, 0x17, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x15, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x74, 0x65,
+	0x61, 0x6d, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x72, 0x61, 0x63, 0x6b,
+	0x5f, 0x73, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f,
+	0x74, 0x65, 0x61, 0x6d, 0x52, 0x61, 0x63, 0x6b, 0x53, 0x68, 0x61, 0x72, 0x69, 0x6e, 0x67, 0x12,
+	0x25, 0x0a, 0x0e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x68, 0x61,
+	0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x70, 0x65, 0x63, 0x74, 0x61, 0x74,
+	0x6f, 0x72, 0x43, 0x68, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x74, 0x5f, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x10, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61,
+	0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0xbe, 0x01, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x72, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x6f, 0x72, 0x66, 0x65, 0x69,
+	0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x66, 0x6f, 0x72, 0x66, 0x65,
+	0x69, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x74, 0x65, 0x61, 0x6d, 0x22, 0x4a, 0x0a, 0x06, 0x53, 0x71, 0x75, 0x61,
+	0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x33, 0x0a, 0x05, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x2a, 0x0a,
+	0x07, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x53, 0x71, 0x75, 0x61, 0x72, 0x65,
+	0x52, 0x07, 0x73, 0x71, 0x75, 0x61, 0x72, 0x65, 0x73, 0x22, 0x30, 0x0a, 0x0a, 0x43, 0x6f, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6f, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x63, 0x6f, 0x6c, 0x22, 0xb8, 0x01, 0x0a, 0x04,
+	0x4d, 0x6f, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x2a, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x26, 0x0a, 0x03,
+	0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x77, 0x6f, 0x72, 0x64,
+	0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c,
+	0x61, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x6c, 0x61, 0x6e,
+	0x6b, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x77, 0x61, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x73, 0x77, 0x61, 0x70, 0x22, 0xd9, 0x02, 0x0a, 0x04, 0x47, 0x61, 0x6d, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61,
+	0x6d, 0x65, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65,
+	0x64, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a,
+	0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x77,
+	0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x75, 0x72, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x74, 0x75, 0x72, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6c, 0x65,
+	0x73, 0x5f, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x63, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x72, 0x61, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x23, 0x0a,
+	0x0d, 0x74, 0x65, 0x61, 0x6d, 0x6d, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x63, 0x6b, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x65, 0x61, 0x6d, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x61,
+	0x63, 0x6b, 0x22, 0x5b, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22,
+	0x4a, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x4b, 0x0a, 0x0f, 0x4a,
+	0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x2f, 0x0a, 0x10, 0x4a, 0x6f, 0x69, 0x6e,
+	0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22, 0x48, 0x0a, 0x10, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x46, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x47,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61,
+	0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d,
+	0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64,
+	0x22, 0x67, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4d,
+	0x6f, 0x76, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0x2b, 0x0a, 0x10, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x32, 0xfb, 0x02, 0x0a, 0x08, 0x57, 0x6f, 0x72, 0x64, 0x47,
+	0x61, 0x6d, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x61, 0x6d,
+	0x65, 0x12, 0x1b, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x08,
+	0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67,
+	0x61, 0x6d, 0x65, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4a,
+	0x6f, 0x69, 0x6e, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x44, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x2e, 0x77,
+	0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67,
+	0x61, 0x6d, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x47, 0x61, 0x6d, 0x65,
+	0x12, 0x18, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x47,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x77, 0x6f, 0x72,
+	0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x50, 0x6c,
+	0x61, 0x79, 0x12, 0x15, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x77, 0x6f, 0x72, 0x64,
+	0x67, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x09, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x61,
+	0x6d, 0x65, 0x30, 0x01, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x66, 0x61, 0x6e, 0x74, 0x61, 0x73, 0x68, 0x6c, 0x65, 0x79, 0x2f, 0x77, 0x6f,
+	0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x77, 0x6f, 0x72, 0x64, 0x67, 0x61, 0x6d, 0x65, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_wordgame_proto_rawDescOnce sync.Once
+	file_wordgame_proto_rawDescData = file_wordgame_proto_rawDesc
+)
+
+func file_wordgame_proto_rawDescGZIP() []byte {
+	file_wordgame_proto_rawDescOnce.Do(func() {
+		file_wordgame_proto_rawDescData = protoimpl.X.CompressGZIP(file_wordgame_proto_rawDescData)
+	})
+	return file_wordgame_proto_rawDescData
+}
+
+var file_wordgame_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_wordgame_proto_goTypes = []interface{}{
+	(*Rules)(nil),               // 0: wordgame.Rules
+	(*Player)(nil),              // 1: wordgame.Player
+	(*Square)(nil),              // 2: wordgame.Square
+	(*Board)(nil),               // 3: wordgame.Board
+	(*Coordinate)(nil),          // 4: wordgame.Coordinate
+	(*Move)(nil),                // 5: wordgame.Move
+	(*Game)(nil),                // 6: wordgame.Game
+	(*CreateGameRequest)(nil),   // 7: wordgame.CreateGameRequest
+	(*CreateGameResponse)(nil),  // 8: wordgame.CreateGameResponse
+	(*JoinGameRequest)(nil),     // 9: wordgame.JoinGameRequest
+	(*JoinGameResponse)(nil),    // 10: wordgame.JoinGameResponse
+	(*StartGameRequest)(nil),    // 11: wordgame.StartGameRequest
+	(*StartGameResponse)(nil),   // 12: wordgame.StartGameResponse
+	(*GetGameRequest)(nil),      // 13: wordgame.GetGameRequest
+	(*PlayRequest)(nil),         // 14: wordgame.PlayRequest
+	(*WatchGameRequest)(nil),    // 15: wordgame.WatchGameRequest
+	(*durationpb.Duration)(nil), // 16: google.protobuf.Duration
+}
+var file_wordgame_proto_depIdxs = []int32{
+	16, // 0: wordgame.Player.time_remaining:type_name -> google.protobuf.Duration
+	2,  // 1: wordgame.Board.squares:type_name -> wordgame.Square
+	4,  // 2: wordgame.Move.start:type_name -> wordgame.Coordinate
+	4,  // 3: wordgame.Move.end:type_name -> wordgame.Coordinate
+	0,  // 4: wordgame.Game.rules:type_name -> wordgame.Rules
+	1,  // 5: wordgame.Game.players:type_name -> wordgame.Player
+	3,  // 6: wordgame.Game.board:type_name -> wordgame.Board
+	0,  // 7: wordgame.CreateGameRequest.rules:type_name -> wordgame.Rules
+	5,  // 8: wordgame.PlayRequest.move:type_name -> wordgame.Move
+	7,  // 9: wordgame.WordGame.CreateGame:input_type -> wordgame.CreateGameRequest
+	9,  // 10: wordgame.WordGame.JoinGame:input_type -> wordgame.JoinGameRequest
+	11, // 11: wordgame.WordGame.StartGame:input_type -> wordgame.StartGameRequest
+	13, // 12: wordgame.WordGame.GetGame:input_type -> wordgame.GetGameRequest
+	14, // 13: wordgame.WordGame.Play:input_type -> wordgame.PlayRequest
+	15, // 14: wordgame.WordGame.WatchGame:input_type -> wordgame.WatchGameRequest
+	8,  // 15: wordgame.WordGame.CreateGame:output_type -> wordgame.CreateGameResponse
+	10, // 16: wordgame.WordGame.JoinGame:output_type -> wordgame.JoinGameResponse
+	12, // 17: wordgame.WordGame.StartGame:output_type -> wordgame.StartGameResponse
+	6,  // 18: wordgame.WordGame.GetGame:output_type -> wordgame.Game
+	6,  // 19: wordgame.WordGame.Play:output_type -> wordgame.Game
+	6,  // 20: wordgame.WordGame.WatchGame:output_type -> wordgame.Game
+	15, // [15:21] is the sub-list for method output_type
+	9,  // [9:15] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_wordgame_proto_init() }
+func file_wordgame_proto_init() {
+	if File_wordgame_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_wordgame_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Player); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Square); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Board); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Coordinate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Move); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Game); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateGameResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JoinGameResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartGameResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wordgame_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_wordgame_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_wordgame_proto_goTypes,
+		DependencyIndexes: file_wordgame_proto_depIdxs,
+		MessageInfos:      file_wordgame_proto_msgTypes,
+	}.Build()
+	File_wordgame_proto = out.File
+	file_wordgame_proto_rawDesc = nil
+	file_wordgame_proto_goTypes = nil
+	file_wordgame_proto_depIdxs = nil
+}
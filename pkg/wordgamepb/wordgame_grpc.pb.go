@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package wordgamepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// WordGameClient is the client API for WordGame service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WordGameClient interface {
+	// CreateGame creates a game. If a player name is given, the creator joins
+	// as the host.
+	CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*CreateGameResponse, error)
+	// JoinGame adds a player to a game that has not started.
+	JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error)
+	// StartGame starts a game. Only the host can start it.
+	StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*StartGameResponse, error)
+	// GetGame gets a game as seen by a player, or by a spectator if no player
+	// ID is given. It never waits for the game to start.
+	GetGame(ctx context.Context, in *GetGameRequest, opts ...grpc.CallOption) (*Game, error)
+	// Play plays, swaps or passes, and returns the game as seen by the player
+	// afterwards.
+	Play(ctx context.Context, in *PlayRequest, opts ...grpc.CallOption) (*Game, error)
+	// WatchGame streams the game as seen by spectators, starting with the
+	// latest state if the game has started, until the client cancels or the
+	// game is over. The last message of a finished game names the winner.
+	WatchGame(ctx context.Context, in *WatchGameRequest, opts ...grpc.CallOption) (WordGame_WatchGameClient, error)
+}
+
+type wordGameClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWordGameClient(cc grpc.ClientConnInterface) WordGameClient {
+	return &wordGameClient{cc}
+}
+
+func (c *wordGameClient) CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*CreateGameResponse, error) {
+	out := new(CreateGameResponse)
+	err := c.cc.Invoke(ctx, "/wordgame.WordGame/CreateGame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordGameClient) JoinGame(ctx context.Context, in *JoinGameRequest, opts ...grpc.CallOption) (*JoinGameResponse, error) {
+	out := new(JoinGameResponse)
+	err := c.cc.Invoke(ctx, "/wordgame.WordGame/JoinGame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordGameClient) StartGame(ctx context.Context, in *StartGameRequest, opts ...grpc.CallOption) (*StartGameResponse, error) {
+	out := new(StartGameResponse)
+	err := c.cc.Invoke(ctx, "/wordgame.WordGame/StartGame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordGameClient) GetGame(ctx context.Context, in *GetGameRequest, opts ...grpc.CallOption) (*Game, error) {
+	out := new(Game)
+	err := c.cc.Invoke(ctx, "/wordgame.WordGame/GetGame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordGameClient) Play(ctx context.Context, in *PlayRequest, opts ...grpc.CallOption) (*Game, error) {
+	out := new(Game)
+	err := c.cc.Invoke(ctx, "/wordgame.WordGame/Play", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wordGameClient) WatchGame(ctx context.Context, in *WatchGameRequest, opts ...grpc.CallOption) (WordGame_WatchGameClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WordGame_ServiceDesc.Streams[0], "/wordgame.WordGame/WatchGame", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &wordGameWatchGameClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WordGame_WatchGameClient interface {
+	Recv() (*Game, error)
+	grpc.ClientStream
+}
+
+type wordGameWatchGameClient struct {
+	grpc.ClientStream
+}
+
+func (x *wordGameWatchGameClient) Recv() (*Game, error) {
+	m := new(Game)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WordGameServer is the server API for WordGame service.
+// All implementations must embed UnimplementedWordGameServer
+// for forward compatibility
+type WordGameServer interface {
+	// CreateGame creates a game. If a player name is given, the creator joins
+	// as the host.
+	CreateGame(context.Context, *CreateGameRequest) (*CreateGameResponse, error)
+	// JoinGame adds a player to a game that has not started.
+	JoinGame(context.Context, *JoinGameRequest) (*JoinGameResponse, error)
+	// StartGame starts a game. Only the host can start it.
+	StartGame(context.Context, *StartGameRequest) (*StartGameResponse, error)
+	// GetGame gets a game as seen by a player, or by a spectator if no player
+	// ID is given. It never waits for the game to start.
+	GetGame(context.Context, *GetGameRequest) (*Game, error)
+	// Play plays, swaps or passes, and returns the game as seen by the player
+	// afterwards.
+	Play(context.Context, *PlayRequest) (*Game, error)
+	// WatchGame streams the game as seen by spectators, starting with the
+	// latest state if the game has started, until the client cancels or the
+	// game is over. The last message of a finished game names the winner.
+	WatchGame(*WatchGameRequest, WordGame_WatchGameServer) error
+	mustEmbedUnimplementedWordGameServer()
+}
+
+// UnimplementedWordGameServer must be embedded to have forward compatible implementations.
+type UnimplementedWordGameServer struct {
+}
+
+func (UnimplementedWordGameServer) CreateGame(context.Context, *CreateGameRequest) (*CreateGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGame not implemented")
+}
+func (UnimplementedWordGameServer) JoinGame(context.Context, *JoinGameRequest) (*JoinGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinGame not implemented")
+}
+func (UnimplementedWordGameServer) StartGame(context.Context, *StartGameRequest) (*StartGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartGame not implemented")
+}
+func (UnimplementedWordGameServer) GetGame(context.Context, *GetGameRequest) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGame not implemented")
+}
+func (UnimplementedWordGameServer) Play(context.Context, *PlayRequest) (*Game, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Play not implemented")
+}
+func (UnimplementedWordGameServer) WatchGame(*WatchGameRequest, WordGame_WatchGameServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchGame not implemented")
+}
+func (UnimplementedWordGameServer) mustEmbedUnimplementedWordGameServer() {}
+
+// UnsafeWordGameServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WordGameServer will
+// result in compilation errors.
+type UnsafeWordGameServer interface {
+	mustEmbedUnimplementedWordGameServer()
+}
+
+func RegisterWordGameServer(s grpc.ServiceRegistrar, srv WordGameServer) {
+	s.RegisterService(&WordGame_ServiceDesc, srv)
+}
+
+func _WordGame_CreateGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordGameServer).CreateGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wordgame.WordGame/CreateGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordGameServer).CreateGame(ctx, req.(*CreateGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WordGame_JoinGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordGameServer).JoinGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wordgame.WordGame/JoinGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordGameServer).JoinGame(ctx, req.(*JoinGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WordGame_StartGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordGameServer).StartGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wordgame.WordGame/StartGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordGameServer).StartGame(ctx, req.(*StartGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WordGame_GetGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordGameServer).GetGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wordgame.WordGame/GetGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordGameServer).GetGame(ctx, req.(*GetGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WordGame_Play_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WordGameServer).Play(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/wordgame.WordGame/Play",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WordGameServer).Play(ctx, req.(*PlayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WordGame_WatchGame_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchGameRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WordGameServer).WatchGame(m, &wordGameWatchGameServer{stream})
+}
+
+type WordGame_WatchGameServer interface {
+	Send(*Game) error
+	grpc.ServerStream
+}
+
+type wordGameWatchGameServer struct {
+	grpc.ServerStream
+}
+
+func (x *wordGameWatchGameServer) Send(m *Game) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// WordGame_ServiceDesc is the grpc.ServiceDesc for WordGame service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WordGame_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wordgame.WordGame",
+	HandlerType: (*WordGameServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGame",
+			Handler:    _WordGame_CreateGame_Handler,
+		},
+		{
+			MethodName: "JoinGame",
+			Handler:    _WordGame_JoinGame_Handler,
+		},
+		{
+			MethodName: "StartGame",
+			Handler:    _WordGame_StartGame_Handler,
+		},
+		{
+			MethodName: "GetGame",
+			Handler:    _WordGame_GetGame_Handler,
+		},
+		{
+			MethodName: "Play",
+			Handler:    _WordGame_Play_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchGame",
+			Handler:       _WordGame_WatchGame_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "wordgame.proto",
+}
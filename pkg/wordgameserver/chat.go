@@ -0,0 +1,315 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// maxChatLength is the maximum number of characters in a chat message
+const maxChatLength = 500
+
+// ChatMessage is a message posted to a game's chat
+type ChatMessage struct {
+	ID        int       `json:"id"`                  // position of the message in the chat
+	Sender    string    `json:"sender"`              // display name of the player or spectator
+	Spectator bool      `json:"spectator,omitempty"` // true if posted by a spectator
+	TeamOnly  bool      `json:"team_only,omitempty"` // true if only the sender's team can see it
+	Text      string    `json:"text"`                // filtered message text
+	Time      time.Time `json:"time"`                // time the message was posted
+	team      int       // team that can see a team-only message
+}
+
+// visibleTo reports whether a player on the team can see the message.
+// Spectators are on team zero and never see team-only messages.
+func (m ChatMessage) visibleTo(team int) bool {
+	return !m.TeamOnly || (team != 0 && m.team == team)
+}
+
+// ChatRequest is the format of the request a client sends to post to, read,
+// or mute a game's chat. Spectators post with a name instead of a player ID.
+type ChatRequest struct {
+	GameID        uuid.UUID  `json:"game_id"`
+	PlayerID      *uuid.UUID `json:"player_id,omitempty"`
+	SpectatorName *string    `json:"spectator_name,omitempty"`
+	Text          string     `json:"text,omitempty"`
+	Since         int        `json:"since,omitempty"` // only return messages with an ID at least this
+	Muted         bool       `json:"muted,omitempty"`
+	TeamOnly      bool       `json:"team_only,omitempty"` // post privately to the sender's team
+}
+
+// chatLog stores a game's chat alongside the rest of its history
+type chatLog struct {
+	sync.Mutex
+	messages []ChatMessage
+	muted    map[uuid.UUID]bool // players who have muted the chat for themselves
+	changed  chan struct{}      // signals the state controller to save the chat
+}
+
+// newChatLog creates an empty chat
+func newChatLog() *chatLog {
+	return &chatLog{
+		messages: make([]ChatMessage, 0),
+		muted:    make(map[uuid.UUID]bool),
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+// markChanged asks the state controller to save the chat without waiting for
+// it. A change still waiting to be saved covers this one too.
+func (c *chatLog) markChanged() {
+	select {
+	case c.changed <- struct{}{}:
+	default:
+	}
+}
+
+// isMuted reports whether the player has muted the chat
+func (c *chatLog) isMuted(playerID uuid.UUID) bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.muted[playerID]
+}
+
+// setMuted mutes or unmutes the chat for the player
+func (c *chatLog) setMuted(playerID uuid.UUID, muted bool) {
+	c.Lock()
+	defer c.Unlock()
+	c.muted[playerID] = muted
+	c.markChanged()
+}
+
+// since returns the messages with an ID of at least the one specified that a
+// player on the team can see
+func (c *chatLog) since(id int, team int) []ChatMessage {
+	c.Lock()
+	defer c.Unlock()
+
+	if id < 0 {
+		id = 0
+	} else if id > len(c.messages) {
+		id = len(c.messages)
+	}
+
+	messages := make([]ChatMessage, 0, len(c.messages)-id)
+	for _, m := range c.messages[id:] {
+		if m.visibleTo(team) {
+			messages = append(messages, m)
+		}
+	}
+	return messages
+}
+
+// filterChat masks the words in the filter list, ignoring case
+func filterChat(text string, filter []string) string {
+	if len(filter) == 0 {
+		return text
+	}
+
+	words := make([]string, len(filter))
+	for i, w := range filter {
+		words[i] = regexp.QuoteMeta(w)
+	}
+
+	re := regexp.MustCompile(`(?i)\b(` + strings.Join(words, "|") + `)\b`)
+	return re.ReplaceAllStringFunc(text, func(w string) string {
+		return strings.Repeat("*", utf8.RuneCountInString(w))
+	})
+}
+
+// postChat validates and stores a message, then pushes it to subscribers who
+// should receive it
+func (sg *ScrabbleGame) postChat(j ChatRequest) (ChatMessage, error) {
+	var m ChatMessage
+
+	text := strings.TrimSpace(j.Text)
+	if text == "" {
+		return m, errors.New("Chat message is empty")
+	} else if utf8.RuneCountInString(text) > maxChatLength {
+		return m, errors.New("Chat message is too long")
+	}
+
+	// Look up every player's team now, since the game's lock cannot be taken
+	// while holding the spectators' lock
+	sg.Lock()
+	rules := sg.Rules
+	teams := make(map[uuid.UUID]int, len(sg.Players))
+	for id, p := range sg.Players {
+		teams[id] = p.Team
+	}
+	sg.Unlock()
+
+	switch {
+	case j.PlayerID != nil:
+		name, ok := sg.playerName(*j.PlayerID)
+		if !ok {
+			return m, errPlayerNotFound
+		}
+		m.Sender = name
+		if j.TeamOnly {
+			m.team = teams[*j.PlayerID]
+			if m.team == 0 {
+				return m, errors.New("Team chat is only available in team games")
+			}
+			m.TeamOnly = true
+		}
+	case j.SpectatorName != nil && *j.SpectatorName != "":
+		if !rules.SpectatorChat {
+			return m, newError(CodeForbidden, "Spectators cannot chat in this game")
+		}
+		m.Sender = *j.SpectatorName
+		m.Spectator = true
+	default:
+		return m, errors.New("Player ID or spectator name is required")
+	}
+
+	m.Text = filterChat(text, rules.ChatFilter)
+	m.Time = time.Now()
+
+	sg.chat.Lock()
+	m.ID = len(sg.chat.messages)
+	sg.chat.messages = append(sg.chat.messages, m)
+	sg.chat.markChanged()
+	sg.chat.Unlock()
+
+	sg.history.record(GameEvent{
+		Type:   "chat",
+		Player: m.Sender,
+		Text:   m.Text,
+		Time:   m.Time,
+		team:   m.team,
+	})
+
+	// Push to players who can see the message and have not muted the chat,
+	// and to spectators if they can take part
+	sg.spectators.Lock()
+	sg.spectators.send(spectatorEvent{Name: "chat", Data: m}, func(playerID *uuid.UUID) bool {
+		if playerID == nil {
+			return rules.SpectatorChat && m.visibleTo(0)
+		}
+		return m.visibleTo(teams[*playerID]) && !sg.chat.isMuted(*playerID)
+	})
+	sg.spectators.Unlock()
+
+	return m, nil
+}
+
+// postChatHandler handles requests from players and spectators to post to the
+// game's chat. It will respond with the stored ChatMessage.
+func postChatHandler(w http.ResponseWriter, r *http.Request) {
+	var j ChatRequest
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	g, err := getGame(j.GameID, w)
+	if err != nil {
+		return
+	}
+
+	m, err := g.postChat(j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := json.Marshal(m)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(resp)
+}
+
+// readChatHandler handles requests to read the game's chat. Players who have
+// muted the chat receive no messages, and team-only messages are only
+// returned to the sender's team.
+func readChatHandler(w http.ResponseWriter, r *http.Request) {
+	var j ChatRequest
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	g, err := getGame(j.GameID, w)
+	if err != nil {
+		return
+	}
+
+	messages := make([]ChatMessage, 0)
+
+	switch {
+	case j.PlayerID != nil:
+		if !g.hasPlayer(*j.PlayerID) {
+			writeError(w, errPlayerNotFound)
+			return
+		}
+		if !g.chat.isMuted(*j.PlayerID) {
+			messages = g.chat.since(j.Since, g.playerTeam(*j.PlayerID))
+		}
+	default:
+		g.Lock()
+		spectatorChat := g.Rules.SpectatorChat
+		g.Unlock()
+		if !spectatorChat {
+			writeError(w, newError(CodeForbidden, "Spectators cannot read the chat in this game"))
+			return
+		}
+		messages = g.chat.since(j.Since, 0)
+	}
+
+	resp, err := json.Marshal(messages)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// muteChatHandler handles requests from players to mute or unmute the game's
+// chat for themselves
+func muteChatHandler(w http.ResponseWriter, r *http.Request) {
+	var j ChatRequest
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return
+	} else if j.PlayerID == nil {
+		writeError(w, errPlayerIDRequired)
+		return
+	}
+
+	g, err := getGame(j.GameID, w)
+	if err != nil {
+		return
+	}
+
+	if !g.hasPlayer(*j.PlayerID) {
+		writeError(w, errPlayerNotFound)
+		return
+	}
+
+	g.chat.setMuted(*j.PlayerID, j.Muted)
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
@@ -0,0 +1,126 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFilterChat(t *testing.T) {
+	filter := []string{"darn", "heck"}
+
+	tests := map[string]string{
+		"Darn, that was a good play": "****, that was a good play",
+		"what the HECK":              "what the ****",
+		"darned tiles":               "darned tiles",
+	}
+
+	for text, expected := range tests {
+		if f := filterChat(text, filter); f != expected {
+			t.Errorf("Filtered %q to %q, expected %q", text, f, expected)
+		}
+	}
+}
+
+func TestChatHandlers(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.ChatFilter = []string{"darn"}
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	spectator := "watcher"
+
+	rr := chatRequest(t, postChatHandler, ChatRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerIDs[0],
+		Text:     "darn good luck",
+	})
+	if c := rr.Code; c != http.StatusCreated {
+		t.Fatalf("Returned status code %v, expected %v. Error: %v", c, http.StatusCreated, rr.Body)
+	}
+
+	// Messages that are too long or from spectators are rejected
+	rr = chatRequest(t, postChatHandler, ChatRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerIDs[1],
+		Text:     strings.Repeat("a", maxChatLength+1),
+	})
+	if rr.Code != http.StatusBadRequest {
+		t.Error("Chat message over length limit should be rejected")
+	}
+
+	rr = chatRequest(t, postChatHandler, ChatRequest{
+		GameID:        newGame.ID,
+		SpectatorName: &spectator,
+		Text:          "hello",
+	})
+	if rr.Code != http.StatusForbidden || responseError(t, rr).Code != CodeForbidden {
+		t.Error("Spectator should not be able to chat by default")
+	}
+
+	rr = chatRequest(t, readChatHandler, ChatRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerIDs[1],
+	})
+
+	var messages []ChatMessage
+	err := json.NewDecoder(rr.Body).Decode(&messages)
+	if err != nil {
+		t.Fatal("Response was not in correct format")
+	} else if len(messages) != 1 {
+		t.Fatalf("Expected 1 chat message, got %v", len(messages))
+	} else if messages[0].Text != "**** good luck" || messages[0].Sender != "ashley1" {
+		t.Fatalf("Unexpected chat message %+v", messages[0])
+	}
+
+	// Muted players no longer receive the chat
+	rr = chatRequest(t, muteChatHandler, ChatRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerIDs[1],
+		Muted:    true,
+	})
+	if c := rr.Code; c != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", c, http.StatusOK)
+	}
+
+	rr = chatRequest(t, readChatHandler, ChatRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerIDs[1],
+	})
+
+	err = json.NewDecoder(rr.Body).Decode(&messages)
+	if err != nil {
+		t.Fatal("Response was not in correct format")
+	} else if len(messages) != 0 {
+		t.Fatalf("Muted player received %v chat messages", len(messages))
+	}
+}
+
+func chatRequest(t *testing.T, handler http.HandlerFunc, j ChatRequest) *httptest.ResponseRecorder {
+	payload, err := json.Marshal(j)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("POST", "/game/chat", bytes.NewBuffer(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
@@ -0,0 +1,132 @@
+package wordgameserver
+
+import "time"
+
+// overtimePenalty is the number of points deducted per minute of overtime
+const overtimePenalty = 10
+
+// gameClock tracks the time used by the player whose turn it is
+type gameClock struct {
+	timer     *time.Timer // fires when the current player's next clock event is due
+	turnStart time.Time   // time the clock was last charged
+}
+
+// expired returns the channel that fires when the current player's clock needs
+// attention, or nil if the game is untimed
+func (c *gameClock) expired() <-chan time.Time {
+	if c.timer == nil {
+		return nil
+	}
+	return c.timer.C
+}
+
+// schedule sets the clock's timer to fire after the specified duration
+func (c *gameClock) schedule(d time.Duration) {
+	if c.timer == nil {
+		c.timer = time.NewTimer(d)
+		return
+	}
+	c.timer.Stop()
+	c.timer.Reset(d)
+}
+
+// startClock gives every player their full time and starts the clock of the
+// player who moves first
+func (sg *ScrabbleGame) startClock(now time.Time) {
+	if sg.Rules.ClockMinutes <= 0 {
+		return
+	}
+
+	limit := time.Duration(sg.Rules.ClockMinutes) * time.Minute
+	for _, p := range sg.Players {
+		p.TimeRemaining = limit
+	}
+
+	sg.clock.turnStart = now
+	sg.clock.schedule(limit)
+}
+
+// chargeClock deducts the time elapsed since it was last charged from the
+// current player's clock. Players who have run out are penalized for each
+// started minute of overtime, or forfeited if the rules say so.
+func (sg *ScrabbleGame) chargeClock(now time.Time) {
+	if sg.Rules.ClockMinutes <= 0 || sg.Finished {
+		return
+	}
+
+	p := sg.currentPlayer()
+	if p == nil || p.Forfeited {
+		return
+	}
+
+	p.TimeRemaining -= now.Sub(sg.clock.turnStart)
+	sg.clock.turnStart = now
+
+	if p.TimeRemaining >= 0 {
+		sg.clock.schedule(p.TimeRemaining)
+		return
+	}
+
+	if sg.Rules.ForfeitOnTime {
+		sg.history.record(GameEvent{
+			Type:   "forfeit",
+			Turn:   sg.TurnCount,
+			Player: p.Name,
+		})
+		sg.forfeit(p, now)
+		return
+	}
+
+	// Charge for every started minute not yet penalized
+	overtime := -p.TimeRemaining
+	minutes := int(overtime/time.Minute) + 1
+	if minutes > p.overtimeMinutes {
+		penalty := (minutes - p.overtimeMinutes) * overtimePenalty
+		p.Score -= penalty
+		sg.history.record(GameEvent{
+			Type:   "penalty",
+			Turn:   sg.TurnCount,
+			Player: p.Name,
+			Rack:   string(p.Tiles),
+			owner:  p.ID,
+			Score:  -penalty,
+		})
+	}
+	p.overtimeMinutes = minutes
+
+	sg.clock.schedule(time.Duration(minutes)*time.Minute - overtime)
+}
+
+// advanceTurn passes the turn to the next player who has not forfeited and
+// starts their clock and turn deadline
+func (sg *ScrabbleGame) advanceTurn(now time.Time) {
+	defer sg.publishTurn()
+
+	for i := 0; i < len(sg.Players); i++ {
+		sg.TurnCount++
+		if p := sg.currentPlayer(); !p.Forfeited {
+			sg.setDeadline(now)
+			if sg.Rules.ClockMinutes > 0 {
+				sg.clock.turnStart = now
+				sg.chargeClock(now)
+			}
+			return
+		}
+	}
+
+	// Every player has forfeited, so there is nobody left to time
+	if sg.clock.timer != nil {
+		sg.clock.timer.Stop()
+	}
+	if sg.deadline.timer != nil {
+		sg.deadline.timer.Stop()
+	}
+}
+
+// currentPlayer returns the player whose turn it is
+func (sg *ScrabbleGame) currentPlayer() *Player {
+	if len(sg.Players) == 0 {
+		return nil
+	}
+	return sg.playerList()[sg.TurnCount%len(sg.Players)]
+}
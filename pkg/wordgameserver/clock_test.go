@@ -0,0 +1,99 @@
+package wordgameserver
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// clockStart is the time the clocks of test games start
+var clockStart = time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+func TestClockOvertimePenalty(t *testing.T) {
+	newGame, playerIDs := newTestGame(t, GameRules{ClockMinutes: 2}, 2)
+	first, second := newGame.Players[playerIDs[0]], newGame.Players[playerIDs[1]]
+
+	// First player runs four and a half minutes over their two
+	newGame.startClock(clockStart)
+	newGame.chargeClock(clockStart.Add(6*time.Minute + 30*time.Second))
+
+	if first.TimeRemaining != -4*time.Minute-30*time.Second {
+		t.Fatalf("Expected first player 4m30s into overtime, has %v remaining", first.TimeRemaining)
+	} else if first.Score != -5*overtimePenalty {
+		t.Errorf("Expected a penalty for each of 5 started minutes, score is %v", first.Score)
+	} else if second.TimeRemaining != 2*time.Minute {
+		t.Errorf("Second player's clock ran while not their turn: %v", second.TimeRemaining)
+	}
+
+	// The same minute is only penalized once
+	newGame.chargeClock(clockStart.Add(6*time.Minute + 50*time.Second))
+	if first.Score != -5*overtimePenalty {
+		t.Errorf("Started minute was penalized twice, score is %v", first.Score)
+	}
+
+	newGame.chargeClock(clockStart.Add(7*time.Minute + time.Second))
+	if first.Score != -6*overtimePenalty {
+		t.Errorf("Expected a penalty for the sixth minute, score is %v", first.Score)
+	}
+}
+
+func TestClockForfeit(t *testing.T) {
+	newGame, playerIDs := newTestGame(t, GameRules{ClockMinutes: 2, ForfeitOnTime: true}, 3)
+	first, second := newGame.Players[playerIDs[0]], newGame.Players[playerIDs[1]]
+
+	newGame.startClock(clockStart)
+	newGame.chargeClock(clockStart.Add(2*time.Minute + time.Second))
+
+	if !first.Forfeited {
+		t.Fatal("Expected first player to forfeit on time")
+	} else if first.Score != 0 {
+		t.Errorf("Forfeited player should not be penalized, score is %v", first.Score)
+	} else if newGame.currentPlayer() != second {
+		t.Errorf("Expected turn to pass to second player, turn is %v", newGame.TurnCount)
+	} else if second.TimeRemaining != 2*time.Minute {
+		t.Errorf("Second player's clock started with %v", second.TimeRemaining)
+	} else if newGame.Finished {
+		t.Error("Game ended with two players left")
+	}
+}
+
+func TestClockForfeitEndsGame(t *testing.T) {
+	newGame, playerIDs := newTestGame(t, GameRules{ClockMinutes: 2, ForfeitOnTime: true}, 2)
+	newGame.Players[playerIDs[0]].Score = 50
+
+	// The only other player wins, whatever the score
+	newGame.startClock(clockStart)
+	newGame.chargeClock(clockStart.Add(2*time.Minute + time.Second))
+
+	if !newGame.Finished {
+		t.Fatal("Game did not end when one player was left")
+	} else if newGame.Winner != "ashley2" {
+		t.Errorf("Expected the player left to win, winner is %q", newGame.Winner)
+	}
+
+	// The clock stops with the game
+	newGame.chargeClock(clockStart.Add(10 * time.Minute))
+	if second := newGame.Players[playerIDs[1]]; second.Forfeited || second.Score != 0 {
+		t.Errorf("Clock ran after the game ended: %+v", *second)
+	}
+}
+
+// newTestGame creates a game with the number of players. Its state controller
+// is not started, so tests drive clocks and deadlines with times of their own.
+func newTestGame(t *testing.T, rules GameRules, players int) (*ScrabbleGame, []uuid.UUID) {
+	newGame := createScrabbleGame()
+	newGame.Rules = rules
+
+	playerIDs := make([]uuid.UUID, players)
+	for i := range playerIDs {
+		id, err := newGame.addPlayer("ashley" + strconv.Itoa(i+1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		playerIDs[i] = id
+	}
+
+	return newGame, playerIDs
+}
@@ -0,0 +1,166 @@
+package wordgameserver
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// turnDeadline tracks the deadline of the current turn in correspondence games
+type turnDeadline struct {
+	timer *time.Timer // fires when the current turn's deadline passes
+}
+
+// expired returns the channel that fires when the current turn's deadline
+// passes, or nil if the game has no deadlines
+func (d *turnDeadline) expired() <-chan time.Time {
+	if d.timer == nil {
+		return nil
+	}
+	return d.timer.C
+}
+
+// arm sets the deadline's timer to fire at the specified time
+func (d *turnDeadline) arm(at time.Time) {
+	wait := time.Until(at)
+	if d.timer == nil {
+		d.timer = time.NewTimer(wait)
+		return
+	}
+	d.timer.Stop()
+	d.timer.Reset(wait)
+}
+
+// turnLength returns how long each turn, or each round of a duplicate game,
+// may take. It is zero if turns have no deadline.
+func (sg *ScrabbleGame) turnLength() time.Duration {
+	if sg.Rules.Duplicate && sg.Rules.DuplicateRoundSeconds > 0 {
+		return time.Duration(sg.Rules.DuplicateRoundSeconds) * time.Second
+	} else if sg.Rules.TurnDeadlineHours > 0 {
+		return time.Duration(sg.Rules.TurnDeadlineHours) * time.Hour
+	}
+	return 0
+}
+
+// startDeadline arms the timer for the current turn. The deadline is stored
+// as a wall-clock time on the game, so a game restored with a deadline keeps
+// it instead of starting the turn over.
+func (sg *ScrabbleGame) startDeadline(now time.Time) {
+	if sg.turnLength() == 0 {
+		return
+	}
+
+	if sg.TurnDeadline.IsZero() {
+		sg.setDeadline(now)
+		return
+	}
+
+	sg.deadline.arm(sg.TurnDeadline)
+}
+
+// setDeadline gives the current player a fresh deadline for their turn
+func (sg *ScrabbleGame) setDeadline(now time.Time) {
+	length := sg.turnLength()
+	if length == 0 {
+		return
+	}
+
+	sg.Lock()
+	sg.TurnDeadline = now.Add(length)
+	sg.Unlock()
+
+	sg.deadline.arm(sg.TurnDeadline)
+}
+
+// deadlinePassed passes the current player's turn, or forfeits them if the
+// rules say so. Either can end the game, like any other pass or forfeit. In
+// duplicate games it ends the round instead.
+func (sg *ScrabbleGame) deadlinePassed(now time.Time) {
+	if now.Before(sg.TurnDeadline) {
+		// Timer was left over from an earlier turn
+		sg.deadline.arm(sg.TurnDeadline)
+		return
+	}
+
+	if sg.Rules.Duplicate {
+		sg.endDuplicateRound(now)
+		return
+	}
+
+	p := sg.currentPlayer()
+	if p == nil {
+		return
+	}
+
+	if sg.Rules.ForfeitOnDeadline {
+		sg.history.record(GameEvent{
+			Type:   "forfeit",
+			Turn:   sg.TurnCount,
+			Player: p.Name,
+			Rack:   string(p.Tiles),
+			owner:  p.ID,
+		})
+		sg.forfeit(p, now)
+		return
+	}
+
+	sg.pass(GamePlayRequest{PlayerID: p.ID})
+	sg.finishTurn(now, sg.playerList())
+}
+
+// publishTurn records who is up next so it can be read without going through
+// the state controller
+func (sg *ScrabbleGame) publishTurn() {
+	var upNext uuid.UUID
+	if p := sg.currentPlayer(); p != nil && !p.Forfeited {
+		upNext = p.ID
+	}
+
+	sg.Lock()
+	sg.upNext = upNext
+	sg.Unlock()
+}
+
+// InboxRequest is the format of the request a client sends to find the games
+// where it is their turn
+type InboxRequest struct {
+	PlayerIDs []uuid.UUID `json:"player_ids"`
+}
+
+// InboxEntry is a game in which the player is up next
+type InboxEntry struct {
+	GameID   uuid.UUID  `json:"game_id"`
+	PlayerID uuid.UUID  `json:"player_id"`
+	Deadline *time.Time `json:"deadline,omitempty"`
+}
+
+// inbox lists the active games in which one of the players is up next
+func inbox(playerIDs []uuid.UUID) []InboxEntry {
+	ids := make(map[uuid.UUID]bool, len(playerIDs))
+	for _, id := range playerIDs {
+		ids[id] = true
+	}
+
+	entries := make([]InboxEntry, 0)
+
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
+	for _, g := range server.activeGames {
+		g.Lock()
+		if g.Active && ids[g.upNext] {
+			e := InboxEntry{
+				GameID:   g.ID,
+				PlayerID: g.upNext,
+			}
+			if !g.TurnDeadline.IsZero() {
+				d := g.TurnDeadline
+				e.Deadline = &d
+			}
+			entries = append(entries, e)
+		}
+		g.Unlock()
+	}
+
+	return entries
+}
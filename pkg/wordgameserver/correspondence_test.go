@@ -0,0 +1,149 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDeadlinePass(t *testing.T) {
+	newGame, playerIDs := newTestGame(t, GameRules{TurnDeadlineHours: 2}, 2)
+	first := newGame.Players[playerIDs[0]]
+
+	newGame.setDeadline(clockStart)
+	deadline := newGame.TurnDeadline
+
+	// A timer left over from an earlier turn does nothing
+	newGame.deadlinePassed(deadline.Add(-time.Minute))
+	if newGame.TurnCount != 0 {
+		t.Fatal("Turn passed before the deadline")
+	}
+
+	// First player misses their deadline and has their turn passed
+	newGame.deadlinePassed(deadline)
+	if newGame.TurnCount != 1 {
+		t.Fatalf("Expected turn to pass to second player, turn count is %v", newGame.TurnCount)
+	} else if first.Forfeited {
+		t.Fatal("Player should not forfeit when deadlines pass turns")
+	} else if !newGame.TurnDeadline.Equal(deadline.Add(2 * time.Hour)) {
+		t.Errorf("Second player's deadline is %v", newGame.TurnDeadline)
+	}
+}
+
+func TestDeadlineForfeit(t *testing.T) {
+	newGame, playerIDs := newTestGame(t, GameRules{TurnDeadlineHours: 2, ForfeitOnDeadline: true}, 3)
+
+	newGame.setDeadline(clockStart)
+	newGame.deadlinePassed(newGame.TurnDeadline)
+
+	if !newGame.Players[playerIDs[0]].Forfeited {
+		t.Fatal("Expected first player to forfeit after missing deadline")
+	} else if newGame.currentPlayer() != newGame.Players[playerIDs[1]] {
+		t.Fatalf("Expected turn to pass to second player, turn count is %v", newGame.TurnCount)
+	} else if newGame.Finished {
+		t.Fatal("Game ended with two players left")
+	}
+
+	// Once only one player is left, they win
+	newGame.deadlinePassed(newGame.TurnDeadline)
+	if !newGame.Finished || newGame.Winner != "ashley3" {
+		t.Fatalf("Expected the game to end with ashley3 winning, winner is %q", newGame.Winner)
+	}
+}
+
+func TestAbandonedGame(t *testing.T) {
+	newGame, _ := newTestGame(t, GameRules{TurnDeadlineHours: 24}, 2)
+
+	// Nobody plays, so every deadline passes the turn until the game ends
+	newGame.setDeadline(clockStart)
+	for turn := 0; turn < scorelessTurnLimit; turn++ {
+		if newGame.Finished {
+			t.Fatalf("Game ended after %v missed deadlines", turn)
+		}
+		newGame.deadlinePassed(newGame.TurnDeadline)
+	}
+
+	if !newGame.Finished {
+		t.Fatal("Game did not end after too many missed deadlines")
+	} else if !newGame.TurnDeadline.IsZero() {
+		t.Errorf("Finished game still has a deadline of %v", newGame.TurnDeadline)
+	}
+}
+
+func TestInboxHandler(t *testing.T) {
+	newGame, playerIDs := startCorrespondenceGame(t, GameRules{TurnDeadlineHours: 1000})
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	// Wait for the state controller to publish the first turn
+	if _, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[0]}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, id := range playerIDs {
+		payload, err := json.Marshal(InboxRequest{PlayerIDs: []uuid.UUID{id}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := http.NewRequest("GET", "/player/inbox", bytes.NewBuffer(payload))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := httptest.NewRecorder()
+		h := http.HandlerFunc(inboxHandler)
+
+		h.ServeHTTP(rr, req)
+
+		if c := rr.Code; c != http.StatusOK {
+			t.Fatalf("Returned status code %v, expected %v", c, http.StatusOK)
+		}
+
+		var entries []InboxEntry
+		err = json.NewDecoder(rr.Body).Decode(&entries)
+		if err != nil {
+			t.Fatal("Response was not in correct format")
+		}
+
+		switch i {
+		case 0:
+			if len(entries) != 1 || entries[0].GameID != newGame.ID {
+				t.Fatalf("Expected game %v in first player's inbox, got %v", newGame.ID, entries)
+			} else if entries[0].Deadline == nil {
+				t.Error("Inbox entry is missing turn deadline")
+			}
+		default:
+			if len(entries) != 0 {
+				t.Fatalf("Expected empty inbox for player not up next, got %v", entries)
+			}
+		}
+	}
+}
+
+func startCorrespondenceGame(t *testing.T, rules GameRules) (*ScrabbleGame, []uuid.UUID) {
+	newGame := createScrabbleGame()
+	newGame.Rules = rules
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		id, err := newGame.addPlayer(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		playerIDs[i] = id
+	}
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	return newGame, playerIDs
+}
@@ -0,0 +1,167 @@
+package wordgameserver
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Dictionary is a word list that plays are validated against, indexed by
+// alphagram so anagrams can be looked up directly
+type Dictionary struct {
+	Name     string
+	words    map[string]bool
+	list     []string            // words in alphabetical order
+	anagrams map[string][]string // words indexed by their alphagram
+}
+
+var (
+	dictionariesMu sync.RWMutex
+	dictionaries   = make(map[string]*Dictionary)
+)
+
+// alphagram sorts the letters of a word into alphabetical order
+func alphagram(word string) string {
+	letters := []byte(strings.ToUpper(word))
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return string(letters)
+}
+
+// NewDictionary reads a word list with one word per line. Words are stored in
+// uppercase, and blank lines and lines starting with # are skipped.
+func NewDictionary(name string, r io.Reader) (*Dictionary, error) {
+	d := &Dictionary{
+		Name:     name,
+		words:    make(map[string]bool),
+		anagrams: make(map[string][]string),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+		if word == "" || strings.HasPrefix(word, "#") || d.words[word] {
+			continue
+		}
+		for i := 0; i < len(word); i++ {
+			if word[i] < 'A' || word[i] > 'Z' {
+				return nil, errors.New("Word '" + word + "' contains letters other than A to Z")
+			}
+		}
+		d.words[word] = true
+		key := alphagram(word)
+		d.anagrams[key] = append(d.anagrams[key], word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, words := range d.anagrams {
+		sort.Strings(words)
+	}
+
+	d.list = make([]string, 0, len(d.words))
+	for w := range d.words {
+		d.list = append(d.list, w)
+	}
+	sort.Strings(d.list)
+
+	return d, nil
+}
+
+// LoadDictionary reads a word list from a file and registers it under the
+// file's name without its extension, so games can choose it in their rules
+func LoadDictionary(path string) (*Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	d, err := NewDictionary(name, f)
+	if err != nil {
+		return nil, errors.Wrap(err, path)
+	}
+
+	RegisterDictionary(d)
+	return d, nil
+}
+
+// RegisterDictionary makes a dictionary available to games by its name
+func RegisterDictionary(d *Dictionary) {
+	dictionariesMu.Lock()
+	dictionaries[d.Name] = d
+	dictionariesMu.Unlock()
+}
+
+// getDictionary looks up a registered dictionary by name
+func getDictionary(name string) (*Dictionary, bool) {
+	dictionariesMu.RLock()
+	defer dictionariesMu.RUnlock()
+	d, ok := dictionaries[name]
+	return d, ok
+}
+
+// Contains reports whether the word is in the dictionary, ignoring case
+func (d *Dictionary) Contains(word string) bool {
+	return d.words[strings.ToUpper(word)]
+}
+
+// Anagrams returns the words made of exactly the letters given, in
+// alphabetical order
+func (d *Dictionary) Anagrams(letters string) []string {
+	return d.anagrams[alphagram(letters)]
+}
+
+// Len returns the number of words in the dictionary
+func (d *Dictionary) Len() int {
+	return len(d.words)
+}
+
+// validateDictionary checks that the rules name a registered dictionary if
+// they need one
+func (r GameRules) validateDictionary() error {
+	if r.Dictionary == "" {
+		if r.Clabbers {
+			return newError(CodeInvalidRules, "Clabbers games need a dictionary")
+		}
+		return nil
+	}
+	if _, ok := getDictionary(r.Dictionary); !ok {
+		return unknownDictionary(r.Dictionary)
+	}
+	return nil
+}
+
+// checkWords validates the words formed by a play against the game's
+// dictionary. In Clabbers games a word is valid if its letters can be
+// rearranged into a dictionary word. Games without a dictionary accept any
+// word.
+func (sg *ScrabbleGame) checkWords(words []string) error {
+	if sg.Rules.Dictionary == "" {
+		return nil
+	}
+
+	d, ok := getDictionary(sg.Rules.Dictionary)
+	if !ok {
+		return unknownDictionary(sg.Rules.Dictionary)
+	}
+
+	for _, w := range words {
+		if sg.Rules.Clabbers {
+			if len(d.Anagrams(w)) == 0 {
+				return newError(CodeInvalidWord, "'"+strings.ToUpper(w)+"' is not an anagram of a word in "+d.Name)
+			}
+		} else if !d.Contains(w) {
+			return newError(CodeInvalidWord, "'"+strings.ToUpper(w)+"' is not a word in "+d.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package wordgameserver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testWords = `# test word list
+cat
+act
+tac
+zoa
+at
+ta
+`
+
+func testDictionary(t *testing.T) *Dictionary {
+	d, err := NewDictionary("test", strings.NewReader(testWords))
+	if err != nil {
+		t.Fatal(err)
+	}
+	RegisterDictionary(d)
+	return d
+}
+
+func TestDictionary(t *testing.T) {
+	d := testDictionary(t)
+
+	if d.Len() != 6 {
+		t.Errorf("Expected 6 words, got %v", d.Len())
+	} else if !d.Contains("Cat") || d.Contains("dog") {
+		t.Error("Dictionary lookup is incorrect")
+	} else if a := d.Anagrams("TCA"); !reflect.DeepEqual(a, []string{"ACT", "CAT", "TAC"}) {
+		t.Errorf("Expected anagrams ACT, CAT and TAC, got %v", a)
+	}
+
+	if _, err := NewDictionary("bad", strings.NewReader("can't\n")); err == nil {
+		t.Error("Words with punctuation should be rejected")
+	}
+}
+
+func TestClabbers(t *testing.T) {
+	testDictionary(t)
+
+	for _, clabbers := range []bool{false, true} {
+		newGame := createScrabbleGame()
+		newGame.Rules.Dictionary = "test"
+		newGame.Rules.Clabbers = clabbers
+
+		playerID, _ := newGame.addPlayer("ashley1")
+		newGame.addPlayer("ashley2")
+		newGame.Players[playerID].Tiles = []byte("TCAZOAE")
+
+		// TCA is not a word, but is an anagram of CAT
+		err := newGame.executePlay(GamePlayRequest{
+			PlayerID: playerID,
+			StartPos: SquareCoordinate{Row: 7, Col: 6},
+			EndPos:   SquareCoordinate{Row: 7, Col: 8},
+			Tiles:    []byte("TCA"),
+		})
+		if clabbers && err != nil {
+			t.Errorf("Anagram should be accepted in Clabbers: %v", err)
+		} else if !clabbers && errorCode(err) != CodeInvalidWord {
+			t.Errorf("Anagram should be rejected with %v without Clabbers, got %v", CodeInvalidWord, err)
+		}
+	}
+
+	rules := GameRules{Clabbers: true}
+	if err := rules.validateDictionary(); err == nil {
+		t.Error("Clabbers without a dictionary should fail")
+	}
+}
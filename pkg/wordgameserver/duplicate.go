@@ -0,0 +1,222 @@
+package wordgameserver
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// duplicateRound is the state of the current round of a duplicate game, in
+// which every player plays from the same rack on the same board
+type duplicateRound struct {
+	rack        []byte                            // rack every player plays from
+	submissions map[uuid.UUID]duplicateSubmission // moves submitted so far this round
+}
+
+// duplicateSubmission is a move a player has submitted secretly for the round
+type duplicateSubmission struct {
+	order     int             // position the move was submitted in, to break ties
+	request   GamePlayRequest // move as submitted
+	placement placement       // validated move, empty for a pass
+}
+
+// validateDuplicate checks that the rules can be used together with the
+// duplicate variant, which has no turns for clocks or teams to act on
+func (r GameRules) validateDuplicate() error {
+	if !r.Duplicate {
+		return nil
+	} else if r.ClockMinutes > 0 || r.Teams {
+		return newError(CodeInvalidRules, "Duplicate games cannot use clocks or teams")
+	}
+	return nil
+}
+
+// startDuplicate draws the first shared rack
+func (sg *ScrabbleGame) startDuplicate() {
+	sg.duplicate = &duplicateRound{
+		rack:        make([]byte, 0, maxTiles),
+		submissions: make(map[uuid.UUID]duplicateSubmission),
+	}
+	sg.refillDuplicateRack()
+}
+
+// refillDuplicateRack tops the shared rack up from the bag and gives every
+// player a copy of it
+func (sg *ScrabbleGame) refillDuplicateRack() {
+	need := maxTiles - len(sg.duplicate.rack)
+	if need > len(sg.TileBag) {
+		need = len(sg.TileBag)
+	}
+
+	sg.duplicate.rack = append(sg.duplicate.rack, sg.TileBag[:need]...)
+	sg.TileBag = sg.TileBag[need:]
+
+	for _, p := range sg.Players {
+		p.Tiles = append([]byte{}, sg.duplicate.rack...)
+	}
+}
+
+// submitDuplicate records a player's secret move for the round. A move with no
+// tiles passes. The round ends once every player has submitted.
+func (sg *ScrabbleGame) submitDuplicate(j GamePlayRequest, now time.Time) error {
+	if sg.Finished {
+		return errGameOver
+	}
+
+	round := sg.duplicate
+
+	j, err := sg.Board.resolveMove(j)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := round.submissions[j.PlayerID]; ok {
+		return newError(CodeMoveSubmitted, "Move already submitted this round")
+	} else if j.Swap {
+		return newError(CodeForbidden, "Tiles cannot be swapped in duplicate games")
+	}
+
+	s := duplicateSubmission{
+		order:   len(round.submissions),
+		request: j,
+	}
+
+	if len(j.Tiles) > 0 {
+		// Check the tiles are on the shared rack without changing it
+		if err := removeTiles(&Player{Tiles: round.rack}, j.Tiles); err != nil {
+			return err
+		}
+
+		p, err := sg.Board.evaluatePlay(j)
+		if err != nil {
+			return err
+		} else if err := sg.checkWords(p.words); err != nil {
+			return err
+		}
+		s.placement = p
+	}
+
+	round.submissions[j.PlayerID] = s
+
+	if len(round.submissions) == len(sg.Players) {
+		sg.endDuplicateRound(now)
+	}
+
+	return nil
+}
+
+// endDuplicateRound scores every player's own move, then places the highest
+// scoring move on the board for everyone. Ties go to the earliest submission.
+// If the game has a dictionary, the engine's top move is placed instead when
+// it outscores every submission, and is recorded without a player. If nobody
+// could play, the rack is returned to the bag and redrawn. The game ends once
+// every tile is played, when nobody can play and the bag is empty, or after
+// too many scoreless rounds in a row.
+func (sg *ScrabbleGame) endDuplicateRound(now time.Time) {
+	round := sg.duplicate
+
+	var best *duplicateSubmission
+	var bestPlayer *Player
+	for id, s := range round.submissions {
+		s := s
+		p := sg.Players[id]
+		p.Score += s.placement.score
+
+		if len(s.placement.tiles) == 0 {
+			continue
+		}
+		if best == nil || s.placement.score > best.placement.score ||
+			(s.placement.score == best.placement.score && s.order < best.order) {
+			best, bestPlayer = &s, p
+		}
+	}
+
+	if top, ok := sg.topDuplicateMove(round.rack); ok && (best == nil || top.placement.score > best.placement.score) {
+		best, bestPlayer = &top, nil
+	}
+
+	// Returning the rack to an empty bag would only draw the same rack again
+	stuck := best == nil && len(sg.TileBag) == 0
+
+	if best != nil {
+		sg.scorelessTurns = 0
+		sg.Board.place(best.placement)
+		move := sg.Board.moveNotation(best.placement.squares)
+
+		rack := &Player{Tiles: round.rack}
+		removeTiles(rack, best.request.Tiles)
+
+		e := GameEvent{
+			Type:  "play",
+			Turn:  sg.TurnCount,
+			Rack:  string(round.rack),
+			Start: &best.request.StartPos,
+			End:   &best.request.EndPos,
+			Tiles: best.placement.played(),
+			Move:  move,
+			Words: best.placement.words,
+			Score: best.placement.score,
+		}
+		if bestPlayer != nil {
+			e.Player, e.owner = bestPlayer.Name, bestPlayer.ID
+		}
+		sg.history.record(e)
+
+		round.rack = rack.Tiles
+	} else {
+		sg.scorelessTurns++
+		sg.TileBag = append(sg.TileBag, round.rack...)
+		sg.TileBag.shuffle()
+		round.rack = round.rack[:0]
+
+		sg.history.record(GameEvent{
+			Type: "pass",
+			Turn: sg.TurnCount,
+		})
+	}
+
+	round.submissions = make(map[uuid.UUID]duplicateSubmission)
+	sg.refillDuplicateRack()
+
+	sg.TurnCount++
+
+	if len(round.rack) == 0 || stuck || sg.scorelessTurns >= scorelessTurnLimit {
+		sg.endDuplicateGame()
+		return
+	}
+	sg.setDeadline(now)
+}
+
+// endDuplicateGame ends a duplicate game. Every player holds the same rack, so
+// nobody goes out and nobody is charged for the tiles left on it.
+func (sg *ScrabbleGame) endDuplicateGame() {
+	playerList := sg.playerList()
+	for _, p := range playerList {
+		p.Tiles = nil
+	}
+	sg.endGame(nil, playerList)
+}
+
+// topDuplicateMove finds the engine's top scoring play of the shared rack. It
+// needs the game's dictionary to generate moves from.
+func (sg *ScrabbleGame) topDuplicateMove(rack []byte) (duplicateSubmission, bool) {
+	if sg.Rules.Dictionary == "" {
+		return duplicateSubmission{}, false
+	}
+	d, ok := getDictionary(sg.Rules.Dictionary)
+	if !ok {
+		return duplicateSubmission{}, false
+	}
+
+	moves := sg.Board.generateMoves(rack, d)
+	if len(moves) == 0 {
+		return duplicateSubmission{}, false
+	}
+
+	j := moves[0].request()
+	p, err := sg.Board.evaluatePlay(j)
+	if err != nil {
+		return duplicateSubmission{}, false
+	}
+	return duplicateSubmission{request: j, placement: p}, true
+}
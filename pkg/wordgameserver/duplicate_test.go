@@ -0,0 +1,207 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDuplicateRound(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.Duplicate = true
+	newGame.TileBag = append(TileBag("CATERSZ"), newGame.TileBag...)
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[1]})
+	if err != nil {
+		t.Fatal(err)
+	} else if string(s.PlayerTiles) != "CATERSZ" {
+		t.Fatalf("Expected shared rack CATERSZ, got %v", string(s.PlayerTiles))
+	}
+
+	// Both players submit at once, since there are no turns
+	_, err = newGame.request(GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: playerIDs[1],
+		StartPos: SquareCoordinate{Row: 7, Col: 6},
+		EndPos:   SquareCoordinate{Row: 7, Col: 8},
+		Tiles:    []byte("CAT"),
+		Play:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = newGame.request(GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: playerIDs[1],
+		Play:     true,
+	})
+	if err == nil {
+		t.Fatal("Player should not be able to submit twice in a round")
+	}
+
+	s, err = newGame.request(GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: playerIDs[0],
+		StartPos: SquareCoordinate{Row: 7, Col: 5},
+		EndPos:   SquareCoordinate{Row: 7, Col: 8},
+		Tiles:    []byte("CATS"),
+		Play:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each player scores their own move, and the best move is placed
+	if s.Players[0].Score != 6 || s.Players[1].Score != 5 {
+		t.Fatalf("Expected scores 6 and 5, got %v and %v", s.Players[0].Score, s.Players[1].Score)
+	} else if s.Board[7][5].Letter != 'C' || s.Board[7][8].Letter != 'S' {
+		t.Fatal("Best move was not placed on the board")
+	}
+
+	other, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.PlayerTiles) != maxTiles || !bytes.Equal(s.PlayerTiles, other.PlayerTiles) {
+		t.Fatalf("Players do not share a full rack: %v and %v", s.PlayerTiles, other.PlayerTiles)
+	} else if !bytes.HasPrefix(s.PlayerTiles, []byte("ERZ")) {
+		t.Fatalf("Unplayed tiles were not kept on the rack: %v", string(s.PlayerTiles))
+	}
+
+	events := newGame.history.list()
+	if e := events[len(events)-1]; e.Type != "play" || e.Player != "ashley1" {
+		t.Fatalf("Placed move not recorded in history: %+v", e)
+	}
+}
+
+func TestDuplicateEngineMove(t *testing.T) {
+	testDictionary(t)
+
+	newGame := createScrabbleGame()
+	newGame.Rules.Duplicate = true
+	newGame.Rules.Dictionary = "test"
+	newGame.TileBag = append(TileBag("CATERSZ"), newGame.TileBag...)
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rounds have no deadline, so the round ends once both players pass
+	var s GameStateResponse
+	for _, id := range playerIDs {
+		var err error
+		s, err = newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: id, Move: "-", Play: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Every three letter word in the test dictionary scores 5 from the star
+	if s.Players[0].Score != 0 || s.Players[1].Score != 0 {
+		t.Fatalf("Players who passed scored %v and %v", s.Players[0].Score, s.Players[1].Score)
+	} else if s.Board[7][7].Letter == 0 {
+		t.Fatal("Engine's move was not placed on the board")
+	}
+
+	events := newGame.history.list()
+	if e := events[len(events)-1]; e.Type != "play" || e.Player != "" || e.Score != 5 {
+		t.Fatalf("Engine's move not recorded in history: %+v", e)
+	}
+}
+
+func TestDuplicateGameEnds(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.Duplicate = true
+	newGame.TileBag = TileBag("CAT")
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Playing every tile in the bag ends the game, with each player keeping
+	// the score of their own move
+	var s GameStateResponse
+	for i, id := range playerIDs {
+		j := GamePlayRequest{GameID: newGame.ID, PlayerID: id, Move: "8G CAT", Play: true}
+		if i == 1 {
+			j.Move = "-"
+		}
+
+		var err error
+		s, err = newGame.request(j)
+		if err != nil {
+			t.Fatal(err)
+		} else if s.Error != nil {
+			t.Fatal(s.Error)
+		}
+	}
+
+	if !newGame.Finished {
+		t.Fatal("Game did not end once every tile was played")
+	} else if newGame.Winner != "ashley1" {
+		t.Errorf("Expected the player who scored to win, winner is %q", newGame.Winner)
+	} else if s.Players[0].Score != 5 || s.Players[1].Score != 0 {
+		t.Errorf("Expected scores of 5 and 0, got %v and %v", s.Players[0].Score, s.Players[1].Score)
+	}
+
+	s, _ = newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[0], Move: "-", Play: true})
+	if errorCode(s.Error) != CodeGameOver {
+		t.Errorf("Expected a move after the end to fail with %v, got %v", CodeGameOver, s.Error)
+	}
+}
+
+func TestDuplicateScorelessRounds(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.Duplicate = true
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	for round := 0; round < scorelessTurnLimit; round++ {
+		if newGame.Finished {
+			t.Fatalf("Game ended after %v scoreless rounds", round)
+		}
+		for _, id := range playerIDs {
+			s, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: id, Move: "-", Play: true})
+			if err != nil {
+				t.Fatal(err)
+			} else if s.Error != nil {
+				t.Fatal(s.Error)
+			}
+		}
+	}
+
+	if !newGame.Finished {
+		t.Fatalf("Game did not end after %v scoreless rounds", scorelessTurnLimit)
+	} else if newGame.Winner != "ashley1 & ashley2" {
+		t.Errorf("Expected a tie, winner is %q", newGame.Winner)
+	}
+}
@@ -0,0 +1,145 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// ErrorCode is a stable, machine-readable reason for a request failing.
+// Messages may change, but codes will not.
+type ErrorCode string
+
+// Error codes returned in ErrorResponse
+const (
+	CodeBadRequest        ErrorCode = "BAD_REQUEST"          // request is malformed or missing fields
+	CodeInternal          ErrorCode = "INTERNAL_ERROR"       // server failed to handle a valid request
+	CodeNotFound          ErrorCode = "NOT_FOUND"            // no endpoint has the path given
+	CodeMethodNotAllowed  ErrorCode = "METHOD_NOT_ALLOWED"   // endpoint does not accept the method given
+	CodeGameNotFound      ErrorCode = "GAME_NOT_FOUND"       // no game has the ID given
+	CodePlayerNotFound    ErrorCode = "PLAYER_NOT_FOUND"     // player is not in the game
+	CodeUnknownDictionary ErrorCode = "UNKNOWN_DICTIONARY"   // no dictionary has the name given
+	CodeInvalidRules      ErrorCode = "INVALID_RULES"        // rules cannot be combined, or need something missing
+	CodeNotHost           ErrorCode = "NOT_HOST"             // only the host can do that
+	CodeForbidden         ErrorCode = "FORBIDDEN"            // the game's rules don't allow it
+	CodeGameStarted       ErrorCode = "GAME_ALREADY_STARTED" // only allowed before the game starts
+	CodeGameNotStarted    ErrorCode = "GAME_NOT_STARTED"     // only allowed once the game starts
+	CodeGameLocked        ErrorCode = "GAME_LOCKED"          // host has locked the game to new players
+	CodeGameFull          ErrorCode = "GAME_FULL"            // game has as many players as it can
+	CodeNotEnoughPlayers  ErrorCode = "NOT_ENOUGH_PLAYERS"   // game cannot start with this many players
+	CodeGameOver          ErrorCode = "GAME_OVER"            // game has finished
+	CodeNotYourTurn       ErrorCode = "NOT_YOUR_TURN"        // another player is up
+	CodeMoveSubmitted     ErrorCode = "MOVE_ALREADY_SUBMITTED"
+	CodeTileNotInRack     ErrorCode = "TILE_NOT_IN_RACK"   // player doesn't have the tiles played
+	CodeNotEnoughTiles    ErrorCode = "NOT_ENOUGH_TILES"   // bag has too few tiles to swap
+	CodeInvalidPlacement  ErrorCode = "INVALID_PLACEMENT"  // tiles are not placed legally
+	CodeInvalidWord       ErrorCode = "INVALID_WORD"       // play forms a word not in the dictionary
+	CodeStreamUnsupported ErrorCode = "STREAM_UNSUPPORTED" // connection cannot stream events
+)
+
+// Errors shared by several handlers
+var (
+	errGameNotFound       = newError(CodeGameNotFound, "No existing game with that ID")
+	errPlayerNotFound     = newError(CodePlayerNotFound, "Player is not in this game")
+	errPlayerIDRequired   = newError(CodeBadRequest, "Player ID is required")
+	errPlayerNameRequired = newError(CodeBadRequest, "Player name is required")
+	errGameStarted        = newError(CodeGameStarted, "Game has already started")
+	errGameNotStarted     = newError(CodeGameNotStarted, "Game has not started")
+	errGameOver           = newError(CodeGameOver, "Game is over")
+)
+
+// unknownDictionary is the error for a dictionary that has not been loaded
+func unknownDictionary(name string) *GameError {
+	return newError(CodeUnknownDictionary, "Unknown dictionary '"+name+"'")
+}
+
+// errorStatus is the HTTP status sent with each code. Codes not listed are
+// sent as bad requests.
+var errorStatus = map[ErrorCode]int{
+	CodeInternal:          http.StatusInternalServerError,
+	CodeNotFound:          http.StatusNotFound,
+	CodeMethodNotAllowed:  http.StatusMethodNotAllowed,
+	CodeGameNotFound:      http.StatusNotFound,
+	CodePlayerNotFound:    http.StatusNotFound,
+	CodeNotHost:           http.StatusForbidden,
+	CodeForbidden:         http.StatusForbidden,
+	CodeGameStarted:       http.StatusConflict,
+	CodeGameNotStarted:    http.StatusConflict,
+	CodeGameLocked:        http.StatusConflict,
+	CodeGameFull:          http.StatusConflict,
+	CodeNotEnoughPlayers:  http.StatusConflict,
+	CodeGameOver:          http.StatusConflict,
+	CodeNotYourTurn:       http.StatusConflict,
+	CodeMoveSubmitted:     http.StatusConflict,
+	CodeNotEnoughTiles:    http.StatusConflict,
+	CodeTileNotInRack:     http.StatusUnprocessableEntity,
+	CodeInvalidPlacement:  http.StatusUnprocessableEntity,
+	CodeInvalidWord:       http.StatusUnprocessableEntity,
+	CodeStreamUnsupported: http.StatusInternalServerError,
+}
+
+// GameError is an error with a code telling clients why their request failed
+type GameError struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e *GameError) Error() string {
+	return e.Message
+}
+
+// newError creates an error with a code
+func newError(code ErrorCode, message string) *GameError {
+	return &GameError{Code: code, Message: message}
+}
+
+// internalError marks an error as the server's fault
+func internalError(err error) *GameError {
+	return newError(CodeInternal, err.Error())
+}
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes why a request failed
+type ErrorDetail struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// errorCode returns the code of an error, which is a bad request for errors
+// without one
+func errorCode(err error) ErrorCode {
+	var e *GameError
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return CodeBadRequest
+}
+
+// httpStatus returns the HTTP status sent with a code
+func httpStatus(code ErrorCode) int {
+	if status, ok := errorStatus[code]; ok {
+		return status
+	}
+	return http.StatusBadRequest
+}
+
+// writeError responds with the error as an ErrorResponse, with the status for
+// its code
+func writeError(w http.ResponseWriter, err error) {
+	code := errorCode(err)
+	status := httpStatus(code)
+
+	resp, _ := json.Marshal(ErrorResponse{
+		Error: ErrorDetail{Code: code, Message: err.Error()},
+	})
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	w.Write(resp)
+}
@@ -0,0 +1,209 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// responseError decodes the error from an error response
+func responseError(t *testing.T, rr *httptest.ResponseRecorder) ErrorDetail {
+	t.Helper()
+
+	var resp ErrorResponse
+	d := json.NewDecoder(bytes.NewReader(rr.Body.Bytes()))
+	if err := d.Decode(&resp); err != nil {
+		t.Fatalf("Error response %q is not JSON: %v", rr.Body, err)
+	} else if d.More() {
+		t.Fatalf("Error response %q has more than one value", rr.Body)
+	}
+	return resp.Error
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   ErrorCode
+	}{
+		{newError(CodeNotYourTurn, "Not yet"), http.StatusConflict, CodeNotYourTurn},
+		{errors.Wrap(errGameNotFound, "Looking up game"), http.StatusNotFound, CodeGameNotFound},
+		{errors.New("Something is missing"), http.StatusBadRequest, CodeBadRequest},
+		{internalError(errors.New("Disk full")), http.StatusInternalServerError, CodeInternal},
+	}
+
+	for _, test := range tests {
+		rr := httptest.NewRecorder()
+		writeError(rr, test.err)
+
+		if rr.Code != test.status {
+			t.Errorf("%v: status %v, expected %v", test.err, rr.Code, test.status)
+		}
+		if ct := rr.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+			t.Errorf("%v: content type %q", test.err, ct)
+		}
+		if e := responseError(t, rr); e.Code != test.code || e.Message != test.err.Error() {
+			t.Errorf("%v: got %+v", test.err, e)
+		}
+	}
+}
+
+func TestPlayErrorResponses(t *testing.T) {
+	testDictionary(t)
+
+	newGame := createScrabbleGame()
+	newGame.Rules.Dictionary = "test"
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := playerIDs[s.PlayerTurn]
+	second := playerIDs[1-s.PlayerTurn]
+	if s, err = newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: first}); err != nil {
+		t.Fatal(err)
+	}
+	rack := s.PlayerTiles
+
+	// A letter the player doesn't have
+	var missing byte
+	for l := byte('A'); l <= 'Z'; l++ {
+		if !bytes.ContainsRune(rack, rune(l)) {
+			missing = l
+			break
+		}
+	}
+
+	// Two tiles that don't spell a word in the test dictionary, with blanks as Q
+	word := GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: first,
+		StartPos: SquareCoordinate{Row: 7, Col: 7},
+		EndPos:   SquareCoordinate{Row: 7, Col: 8},
+	}
+	for i := 0; i < len(rack) && word.Tiles == nil; i++ {
+		for j := i + 1; j < len(rack) && word.Tiles == nil; j++ {
+			if pair := string([]byte{rack[i], rack[j]}); pair != "AT" && pair != "TA" {
+				word.Tiles = []byte(pair)
+			}
+		}
+	}
+	for _, l := range word.Tiles {
+		if l == ' ' {
+			word.Blanks = append(word.Blanks, 'Q')
+		}
+	}
+
+	tests := []struct {
+		name   string
+		req    GamePlayRequest
+		status int
+		code   ErrorCode
+	}{
+		{"out of turn", GamePlayRequest{GameID: newGame.ID, PlayerID: second, Move: "-"},
+			http.StatusConflict, CodeNotYourTurn},
+		{"tile not in rack", GamePlayRequest{GameID: newGame.ID, PlayerID: first, Move: "8H " + string([]byte{missing, missing})},
+			http.StatusUnprocessableEntity, CodeTileNotInRack},
+		{"off the star", GamePlayRequest{GameID: newGame.ID, PlayerID: first, Move: "1A " + string(rack[:2])},
+			http.StatusUnprocessableEntity, CodeInvalidPlacement},
+		{"invalid word", word, http.StatusUnprocessableEntity, CodeInvalidWord},
+		{"unknown game", GamePlayRequest{GameID: uuid.New(), PlayerID: first},
+			http.StatusNotFound, CodeGameNotFound},
+		{"unknown player", GamePlayRequest{GameID: newGame.ID, PlayerID: uuid.New(), Move: "-"},
+			http.StatusNotFound, CodePlayerNotFound},
+	}
+
+	for _, test := range tests {
+		rr := playRequest(t, test.req)
+		if rr.Code != test.status {
+			t.Errorf("%s: status %v, expected %v. Body: %v", test.name, rr.Code, test.status, rr.Body)
+			continue
+		}
+		if e := responseError(t, rr); e.Code != test.code {
+			t.Errorf("%s: code %v, expected %v", test.name, e.Code, test.code)
+		}
+	}
+
+	// State requests need a player
+	payload, _ := json.Marshal(GeneralGameRequest{GameID: newGame.ID})
+	rr := httptest.NewRecorder()
+	gameStateHandler(rr, httptest.NewRequest("POST", "/game/state", bytes.NewReader(payload)))
+	if rr.Code != http.StatusBadRequest || responseError(t, rr).Code != CodeBadRequest {
+		t.Errorf("State without a player: status %v, body %v", rr.Code, rr.Body)
+	}
+}
+
+// serveWithin serves a request through the router, failing if it takes longer
+// than a few seconds
+func serveWithin(t *testing.T, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		newRouter().ServeHTTP(rr, httptest.NewRequest(method, path, bytes.NewReader(payload)))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return rr
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s %s is still waiting", method, path)
+		return nil
+	}
+}
+
+func TestNotStartedErrors(t *testing.T) {
+	newGame := createScrabbleGame()
+	playerID, _ := newGame.addPlayer("ashley1")
+	newGame.addPlayer("ashley2")
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	state := GeneralGameRequest{GameID: newGame.ID, PlayerID: &playerID}
+	pass := GamePlayRequest{GameID: newGame.ID, PlayerID: playerID, Move: "-"}
+	tests := []struct {
+		method string
+		path   string
+		body   interface{}
+	}{
+		{"POST", "/game/state", state},
+		{"POST", "/game/state?format=text", state},
+		{"POST", "/game/play", pass},
+		{"POST", "/v2/games/" + newGame.ID.String() + "/moves", pass},
+	}
+
+	for _, test := range tests {
+		rr := serveWithin(t, test.method, test.path, test.body)
+		if rr.Code != http.StatusConflict || responseError(t, rr).Code != CodeGameNotStarted {
+			t.Errorf("%s %s: status %v, body %v", test.method, test.path, rr.Code, rr.Body)
+		}
+	}
+}
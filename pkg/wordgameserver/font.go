@@ -0,0 +1,91 @@
+package wordgameserver
+
+import (
+	"image"
+	"image/color"
+)
+
+// Size of a glyph in the bitmap font, in pixels before scaling
+const (
+	glyphWidth  = 5
+	glyphHeight = 7
+)
+
+// glyphs is a small bitmap font for drawing text on PNG boards, since the
+// standard library has no fonts. Lowercase letters are drawn as uppercase and
+// characters without a glyph are left blank.
+var glyphs = map[byte][glyphHeight]string{
+	'0': {" ### ", "#   #", "#  ##", "# # #", "##  #", "#   #", " ### "},
+	'1': {"  #  ", " ##  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+	'2': {" ### ", "#   #", "    #", "   # ", "  #  ", " #   ", "#####"},
+	'3': {"#####", "   # ", "  #  ", "   # ", "    #", "#   #", " ### "},
+	'4': {"   # ", "  ## ", " # # ", "#  # ", "#####", "   # ", "   # "},
+	'5': {"#####", "#    ", "#### ", "    #", "    #", "#   #", " ### "},
+	'6': {"  ## ", " #   ", "#    ", "#### ", "#   #", "#   #", " ### "},
+	'7': {"#####", "    #", "   # ", "  #  ", " #   ", " #   ", " #   "},
+	'8': {" ### ", "#   #", "#   #", " ### ", "#   #", "#   #", " ### "},
+	'9': {" ### ", "#   #", "#   #", " ####", "    #", "   # ", " ##  "},
+	'A': {" ### ", "#   #", "#   #", "#####", "#   #", "#   #", "#   #"},
+	'B': {"#### ", "#   #", "#   #", "#### ", "#   #", "#   #", "#### "},
+	'C': {" ### ", "#   #", "#    ", "#    ", "#    ", "#   #", " ### "},
+	'D': {"#### ", "#   #", "#   #", "#   #", "#   #", "#   #", "#### "},
+	'E': {"#####", "#    ", "#    ", "#### ", "#    ", "#    ", "#####"},
+	'F': {"#####", "#    ", "#    ", "#### ", "#    ", "#    ", "#    "},
+	'G': {" ### ", "#   #", "#    ", "# ###", "#   #", "#   #", " ####"},
+	'H': {"#   #", "#   #", "#   #", "#####", "#   #", "#   #", "#   #"},
+	'I': {" ### ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+	'J': {"  ###", "   # ", "   # ", "   # ", "   # ", "#  # ", " ##  "},
+	'K': {"#   #", "#  # ", "# #  ", "##   ", "# #  ", "#  # ", "#   #"},
+	'L': {"#    ", "#    ", "#    ", "#    ", "#    ", "#    ", "#####"},
+	'M': {"#   #", "## ##", "# # #", "# # #", "#   #", "#   #", "#   #"},
+	'N': {"#   #", "#   #", "##  #", "# # #", "#  ##", "#   #", "#   #"},
+	'O': {" ### ", "#   #", "#   #", "#   #", "#   #", "#   #", " ### "},
+	'P': {"#### ", "#   #", "#   #", "#### ", "#    ", "#    ", "#    "},
+	'Q': {" ### ", "#   #", "#   #", "#   #", "# # #", "#  # ", " ## #"},
+	'R': {"#### ", "#   #", "#   #", "#### ", "# #  ", "#  # ", "#   #"},
+	'S': {" ####", "#    ", "#    ", " ### ", "    #", "    #", "#### "},
+	'T': {"#####", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  "},
+	'U': {"#   #", "#   #", "#   #", "#   #", "#   #", "#   #", " ### "},
+	'V': {"#   #", "#   #", "#   #", "#   #", "#   #", " # # ", "  #  "},
+	'W': {"#   #", "#   #", "#   #", "# # #", "# # #", "# # #", " # # "},
+	'X': {"#   #", "#   #", " # # ", "  #  ", " # # ", "#   #", "#   #"},
+	'Y': {"#   #", "#   #", " # # ", "  #  ", "  #  ", "  #  ", "  #  "},
+	'Z': {"#####", "    #", "   # ", "  #  ", " #   ", "#    ", "#####"},
+	':': {"     ", "  #  ", "  #  ", "     ", "  #  ", "  #  ", "     "},
+	'-': {"     ", "     ", "     ", "#####", "     ", "     ", "     "},
+	'?': {" ### ", "#   #", "    #", "   # ", "  #  ", "     ", "  #  "},
+	'*': {"     ", "  #  ", "# # #", " ### ", "# # #", "  #  ", "     "},
+}
+
+// textWidth returns the width in pixels of text drawn at the scale
+func textWidth(s string, scale int) int {
+	if len(s) == 0 {
+		return 0
+	}
+	return (len(s)*(glyphWidth+1) - 1) * scale
+}
+
+// drawText draws text with its top left corner at the point, with each pixel
+// of the font scaled up to a square of the given size
+func drawText(img *image.RGBA, s string, x, y, scale int, c color.Color) {
+	for i := 0; i < len(s); i++ {
+		l := s[i]
+		if l >= 'a' && l <= 'z' {
+			l -= 'a' - 'A'
+		}
+		glyph := glyphs[l]
+		for row, line := range glyph {
+			for col := 0; col < len(line); col++ {
+				if line[col] != '#' {
+					continue
+				}
+				for dy := 0; dy < scale; dy++ {
+					for dx := 0; dx < scale; dx++ {
+						img.Set(x+(col*scale)+dx, y+(row*scale)+dy, c)
+					}
+				}
+			}
+		}
+		x += (glyphWidth + 1) * scale
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/pkg/errors"
 )
 
 // Tile represents a Scrabble tile that would be played on a board
@@ -55,6 +54,13 @@ type Player struct {
 	Score  int                    `json:"score"`  // current score in the game
 	State  chan GameStateResponse `json:"-"`      // channel on which to send state responses
 	Play   chan GameStateResponse `json:"-"`      // channel on which to send play responses
+
+	TimeRemaining   time.Duration `json:"time_remaining,omitempty"` // time left on the player's clock, negative in overtime
+	Forfeited       bool          `json:"forfeited,omitempty"`      // true if the player lost on time or missed a deadline
+	Team            int           `json:"team,omitempty"`           // team the player plays for, zero if not a team game
+	Grid            []GridTile    `json:"-"`                        // private grid in the speed variant
+	overtimeMinutes int           // minutes of overtime already penalized
+	teamChoice      int           // team the player chose when joining, zero if they did not choose
 }
 
 // TileBag represents the bag of undistributed tiles in a game
@@ -64,16 +70,35 @@ var initializedTileBag = initializeTileBag()
 
 const maxTiles = 7
 
+const maxPlayers = 4
+
 // ScrabbleGame represents the state of an active game instance
 type ScrabbleGame struct {
 	sync.Mutex
 	ID        uuid.UUID             // unique identifier
 	Active    bool                  // true if the game has started
+	Locked    bool                  // true if the host has closed the game to new players
+	HostID    uuid.UUID             // player who manages the game before it starts
+	Rules     GameRules             // options chosen when the game was created
 	Action    chan GamePlayRequest  // channel for receiving player's turns
 	TurnCount int                   // counter that increments for each turn played
 	Board     ScrabbleBoard         // board representation with current tiles
 	TileBag   TileBag               // bag of tiles not yet distributed
 	Players   map[uuid.UUID]*Player // players indexed by UUID
+	clock     gameClock             // clock of the player whose turn it is
+
+	TurnDeadline time.Time    // time the current turn must be played by in correspondence games
+	deadline     turnDeadline // timer for the current turn's deadline
+	upNext       uuid.UUID    // player whose turn it is, readable while holding the lock
+	spectators   *spectators  // subscribers watching the game without playing
+	chat         *chatLog     // messages posted by players and spectators
+	history      *gameHistory // events that have happened in the game
+
+	duplicate *duplicateRound // current round of a duplicate game
+	Winner    string          // name of the winner once the game is over, or of everyone tied for the lead
+	Finished  bool            // game is over and can no longer be played, readable while holding the lock
+
+	scorelessTurns int // passes, swaps or scoreless duplicate rounds in a row, which end the game once there are too many
 }
 
 // createScrabbleGame initializes a game instance
@@ -85,6 +110,10 @@ func createScrabbleGame() *ScrabbleGame {
 
 	game.Action = make(chan GamePlayRequest)
 
+	game.spectators = newSpectators()
+	game.chat = newChatLog()
+	game.history = newGameHistory()
+
 	// Initialize squares on board
 	game.Board = initializedBoard
 
@@ -108,23 +137,27 @@ func dealTiles(p *Player, tb *TileBag, tileCount int) {
 	p.Tiles = append(p.Tiles, tilesDealt...)
 }
 
+// removeTiles takes tiles out of the player's hand. The hand is left
+// unchanged if any of the tiles are missing.
 func removeTiles(p *Player, tiles []byte) error {
 	var tileFound bool
+	hand := append([]byte{}, p.Tiles...)
 	for _, t := range tiles {
 		tileFound = false
-		for i, pt := range p.Tiles {
+		for i, pt := range hand {
 			// Check for matching tile in player's hand
 			if t == pt {
 				// Remove tile from player's hand
-				p.Tiles = append(p.Tiles[:i], p.Tiles[i+1:]...)
+				hand = append(hand[:i], hand[i+1:]...)
 				tileFound = true
 				break
 			}
 		}
 		if !tileFound {
-			return errors.New("Tile '" + string(t) + "' not in player's hand")
+			return newError(CodeTileNotInRack, "Tile '"+string(t)+"' not in player's hand")
 		}
 	}
+	p.Tiles = hand
 	return nil
 }
 
@@ -150,12 +183,25 @@ func (tb TileBag) shuffle() {
 
 func (sg *ScrabbleGame) start() error {
 
-	if sg.Active {
-		return errors.New("Game has already started")
+	if sg.Finished {
+		return errGameOver
+	} else if sg.Active {
+		return errGameStarted
 	} else if len(sg.Players) < 2 {
-		return errors.New("At least two players needed to start game")
+		return newError(CodeNotEnoughPlayers, "At least two players needed to start game")
+	}
+
+	if err := sg.Rules.validate(); err != nil {
+		return err
+	} else if err := sg.validateTeams(); err != nil {
+		return err
 	}
 
+	// Nothing can fail from here on, so the game is never left half started.
+	// Decide who goes first, then seat teammates opposite each other.
+	sg.orderPlayers()
+	sg.assignTeams()
+
 	sg.Active = true
 
 	go sg.stateController()
@@ -167,46 +213,139 @@ func (sg *ScrabbleGame) start() error {
 // requests and play requests
 func (sg *ScrabbleGame) stateController() {
 
-	// Deal tiles to players
-	for p := range sg.Players {
-		dealTiles(sg.Players[p], &sg.TileBag, 7)
+	// Deal tiles to players, or the shared rack in duplicate games
+	switch {
+	case sg.Rules.Duplicate:
+		sg.startDuplicate()
+	case sg.Rules.Speed:
+		for p := range sg.Players {
+			dealTiles(sg.Players[p], &sg.TileBag, speedStartTiles)
+		}
+	default:
+		for p := range sg.Players {
+			dealTiles(sg.Players[p], &sg.TileBag, maxTiles)
+		}
+	}
+
+	now := time.Now()
+	sg.startClock(now)
+	sg.run(now)
+}
+
+// resumeController is the state controller of a restored game. The current
+// player's clock picks up where it was saved, and a deadline that passed
+// while the game was not running is handled straight away.
+func (sg *ScrabbleGame) resumeController() {
+	now := time.Now()
+	if sg.Rules.ClockMinutes > 0 && !sg.Finished {
+		sg.clock.turnStart = now
+		sg.chargeClock(now)
 	}
+	sg.run(now)
+}
 
+// run starts the current turn's deadline, then handles requests and timers
+// until the game's action channel is closed
+func (sg *ScrabbleGame) run(now time.Time) {
 	// Get ordered list of players to send to clients
 	playerList := sg.playerList()
 
-	// Loop on requests in queue
-	for request := range sg.Action {
-		switch request.Play {
-		case false: // Return the game state
-			sg.Players[request.PlayerID].State <- sg.getState(request.PlayerID, playerList)
-		default: // Execute play
-			err := sg.executePlay(request)
-			gameState := sg.getState(request.PlayerID, playerList)
-			if err != nil {
+	if !sg.Finished {
+		sg.startDeadline(now)
+		sg.publishTurn()
+	}
+	sg.broadcast(playerList)
+	sg.save()
+
+	// Loop on requests in queue, and on the clock or turn deadline running
+	// out while no requests are arriving
+	for {
+		select {
+		case request, ok := <-sg.Action:
+			if !ok {
+				return
+			}
+
+			// Bring the current player's clock up to date before acting
+			sg.chargeClock(time.Now())
+
+			switch {
+			case !request.Play: // Return the game state
+				sg.Players[request.PlayerID].State <- sg.getState(request.PlayerID, playerList)
+			case sg.Rules.Speed: // Build, peel or dump on the player's own grid
+				err := sg.speedAction(request)
+				if err == nil {
+					sg.broadcast(playerList)
+				}
+				gameState := sg.getState(request.PlayerID, playerList)
 				gameState.Error = err
+				sg.Players[request.PlayerID].Play <- gameState
+			case sg.Rules.Duplicate: // Submit move for the round
+				err := sg.submitDuplicate(request, time.Now())
+				if err == nil {
+					sg.broadcast(playerList)
+				}
+				gameState := sg.getState(request.PlayerID, playerList)
+				gameState.Error = err
+				sg.Players[request.PlayerID].Play <- gameState
+			default: // Execute play
+				err := sg.executePlay(request)
+				if err == nil {
+					sg.finishTurn(time.Now(), playerList)
+					sg.broadcast(playerList)
+					sg.save()
+				}
+				gameState := sg.getState(request.PlayerID, playerList)
+				if err != nil {
+					gameState.Error = err
+				}
+				sg.Players[request.PlayerID].Play <- gameState
 			}
-			sg.Players[request.PlayerID].Play <- gameState
+		case <-sg.clock.expired():
+			sg.chargeClock(time.Now())
+			sg.broadcast(playerList)
+			sg.save()
+		case <-sg.deadline.expired():
+			sg.chargeClock(time.Now())
+			sg.deadlinePassed(time.Now())
+			sg.broadcast(playerList)
+			sg.save()
+		case <-sg.chat.changed:
+			sg.save()
 		}
 	}
 }
 
+// request sends a play or state request to the state controller and waits for
+// the player's state in response. The player and the game having started are
+// checked under the same lock, so the request never reaches a controller that
+// does not exist or names a player it cannot answer. Games that finished
+// without being played here, such as imported records, have no controller.
 func (sg *ScrabbleGame) request(r GamePlayRequest) (GameStateResponse, error) {
-
-	var j GameStateResponse
+	sg.Lock()
+	p, ok := sg.Players[r.PlayerID]
+	active, finished := sg.Active, sg.Finished
+	sg.Unlock()
+
+	if !ok {
+		return GameStateResponse{}, errPlayerNotFound
+	} else if !active && finished {
+		return GameStateResponse{}, errGameOver
+	} else if !active {
+		return GameStateResponse{}, errGameNotStarted
+	}
 
 	// Send request to game controller
 	sg.Action <- r
 
-	switch r.Play {
-	case false:
-		return <-sg.Players[r.PlayerID].State, nil
-	default:
-		if j = <-sg.Players[r.PlayerID].Play; j.Error != nil {
-			return j, j.Error
-		}
-		return j, nil
+	if !r.Play {
+		return <-p.State, nil
 	}
+	j := <-p.Play
+	if j.Error != nil {
+		return j, j.Error
+	}
+	return j, nil
 }
 
 // playerList generates an ordered list of players for consistency across all
@@ -219,15 +358,63 @@ func (sg *ScrabbleGame) playerList() []*Player {
 	return p
 }
 
+// snapshotPlayers copies the players so they can be encoded outside the state
+// controller
+func snapshotPlayers(playerList []*Player) []*Player {
+	players := make([]*Player, len(playerList))
+	for i, p := range playerList {
+		cp := *p
+		players[i] = &cp
+	}
+	return players
+}
+
+// getState builds the game state as seen by the specified player. Players and
+// tiles are copied, since the response is encoded outside the state
+// controller.
 func (sg *ScrabbleGame) getState(playerID uuid.UUID, playerList []*Player) GameStateResponse {
-	return GameStateResponse{
-		GameID:      sg.ID,
-		PlayerID:    playerID,
-		Players:     playerList,
-		Board:       sg.Board,
-		PlayerTurn:  sg.TurnCount % len(playerList),
-		PlayerTiles: sg.Players[playerID].Tiles,
+	players := snapshotPlayers(playerList)
+
+	playerTiles := make([]byte, len(sg.Players[playerID].Tiles))
+	copy(playerTiles, sg.Players[playerID].Tiles)
+
+	s := GameStateResponse{
+		GameID:         sg.ID,
+		PlayerID:       playerID,
+		Players:        players,
+		Board:          sg.Board,
+		PlayerTurn:     sg.TurnCount % len(playerList),
+		PlayerTiles:    playerTiles,
+		TilesRemaining: len(sg.TileBag),
+		Teams:          teamScores(playerList),
+		Grid:           append([]GridTile{}, sg.Players[playerID].Grid...),
+		Winner:         sg.Winner,
 	}
+
+	// Teammates may be allowed to see each other's racks
+	if tm := teammate(playerList, sg.Players[playerID]); tm != nil && sg.Rules.TeamRackSharing {
+		s.TeammateTiles = make([]byte, len(tm.Tiles))
+		copy(s.TeammateTiles, tm.Tiles)
+	}
+
+	return s
+}
+
+// playerName looks up the name of a player in the game
+func (sg *ScrabbleGame) playerName(playerID uuid.UUID) (string, bool) {
+	sg.Lock()
+	defer sg.Unlock()
+	p, ok := sg.Players[playerID]
+	if !ok {
+		return "", false
+	}
+	return p.Name, true
+}
+
+// hasPlayer reports whether the player is in the game
+func (sg *ScrabbleGame) hasPlayer(playerID uuid.UUID) bool {
+	_, ok := sg.playerName(playerID)
+	return ok
 }
 
 // addPlayer checks that a new player can be added to the game, and adds the
@@ -240,15 +427,20 @@ func (sg *ScrabbleGame) addPlayer(name string) (uuid.UUID, error) {
 		Name:  name,
 		Tiles: make([]byte, 0),
 		State: make(chan GameStateResponse),
+		Play:  make(chan GameStateResponse),
 	}
 
 	playerCount := len(sg.Players)
 
 	// Check that game is valid to join
-	if sg.Active {
-		return p.ID, errors.New("Game has already started")
-	} else if playerCount == 4 {
-		return p.ID, errors.New("Maximum players reached for game")
+	if sg.Finished {
+		return p.ID, errGameOver
+	} else if sg.Active {
+		return p.ID, errGameStarted
+	} else if sg.Locked {
+		return p.ID, newError(CodeGameLocked, "Game is locked")
+	} else if playerCount == maxPlayers {
+		return p.ID, newError(CodeGameFull, "Maximum players reached for game")
 	}
 
 	// Assign player their number based on when they joined
@@ -256,5 +448,10 @@ func (sg *ScrabbleGame) addPlayer(name string) (uuid.UUID, error) {
 	// Add player to game
 	sg.Players[p.ID] = &p
 
+	// The first player to join hosts the game
+	if sg.HostID == uuid.Nil {
+		sg.HostID = p.ID
+	}
+
 	return p.ID, nil
 }
@@ -0,0 +1,368 @@
+package wordgameserver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// maxGCGSize is the largest GCG file accepted for import
+const maxGCGSize = 1 << 20
+
+// gcgRack writes a rack in GCG notation, with blanks as ?
+func gcgRack(rack string) string {
+	return strings.Replace(rack, " ", "?", -1)
+}
+
+// parseGCGRack reads a rack in GCG notation, turning ? back into blanks
+func parseGCGRack(rack string) string {
+	return strings.Replace(strings.ToUpper(rack), "?", " ", -1)
+}
+
+// gcgNicks gives each player a nickname without spaces, as GCG requires
+func gcgNicks(names []string) map[string]string {
+	nicks := make(map[string]string, len(names))
+	used := make(map[string]bool, len(names))
+	for i, name := range names {
+		nick := strings.Join(strings.Fields(name), "_")
+		if nick == "" || used[nick] {
+			nick += "_" + strconv.Itoa(i+1)
+		}
+		nicks[name], used[nick] = nick, true
+	}
+	return nicks
+}
+
+// writeGCG writes a game record in GCG format from the game's history. Plays
+// are checked against the dictionary when they are made, so only imported
+// games have challenges and withdrawn plays.
+func writeGCG(w io.Writer, id uuid.UUID, rules GameRules, names []string, events []GameEvent) error {
+	var b bytes.Buffer
+	nicks := gcgNicks(names)
+
+	fmt.Fprintln(&b, "#character-encoding UTF-8")
+	fmt.Fprintf(&b, "#id wordgame-controller %s\n", id)
+	if rules.Dictionary != "" {
+		fmt.Fprintf(&b, "#lexicon %s\n", rules.Dictionary)
+	}
+	for i, name := range names {
+		fmt.Fprintf(&b, "#player%d %s %s\n", i+1, nicks[name], name)
+	}
+
+	board := initializedBoard
+	totals := make(map[string]int, len(names))
+	var lastPlay []SquareCoordinate
+	for _, e := range events {
+		nick, ok := nicks[e.Player]
+		if !ok {
+			continue
+		}
+
+		var move []string
+		switch e.Type {
+		case "play":
+			squares, err := board.replayPlay(e)
+			if err != nil {
+				return errors.Wrapf(err, "Turn %d", e.Turn)
+			}
+			lastPlay = squares
+
+			// Letters already on the board are written as dots
+			start, across, letters, through := board.mainWord(squares)
+			for i := range letters {
+				if through[i] {
+					letters[i] = '.'
+				}
+			}
+			move = []string{coordinate(start, across), string(letters)}
+		case "swap":
+			swapped := gcgRack(e.Tiles)
+			if swapped == "" {
+				swapped = strconv.Itoa(e.Count)
+			}
+			move = []string{"-" + swapped}
+		case "pass":
+			move = []string{"-"}
+		case "withdrawn":
+			for _, sc := range lastPlay {
+				board[sc.Row][sc.Col].Tile = Tile{}
+			}
+			lastPlay = nil
+			move = []string{"--"}
+		case "challenge":
+			move = []string{"(challenge)"}
+		case "penalty":
+			move = []string{"(time)"}
+		case "endrack":
+			move = []string{"(" + gcgRack(e.Tiles) + ")"}
+		case "forfeit":
+			fmt.Fprintf(&b, "#note %s forfeited\n", nick)
+			continue
+		default:
+			continue
+		}
+
+		totals[e.Player] += e.Score
+		line := []string{">" + nick + ":"}
+		if e.Rack != "" {
+			line = append(line, gcgRack(e.Rack))
+		}
+		line = append(line, move...)
+		line = append(line, fmt.Sprintf("%+d", e.Score), strconv.Itoa(totals[e.Player]))
+		fmt.Fprintln(&b, strings.Join(line, " "))
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// readGCG reads a game record in GCG format into a new game for review. The
+// game is finished and never started, so it cannot be joined or played and
+// its board and history are exactly as recorded. Racks are not known after
+// the record ends, so every tile not on the board is left in the bag.
+func readGCG(r io.Reader) (*ScrabbleGame, error) {
+	sg := createScrabbleGame()
+	players := make(map[string]*Player)
+	var lastPlay placement
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		lineErr := func(msg string) error {
+			return errors.Errorf("Line %d: %s", lineNo, msg)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "#player"):
+			if len(fields) < 2 {
+				return nil, lineErr("Player has no nickname")
+			}
+			name := strings.Join(fields[2:], " ")
+			if name == "" {
+				name = fields[1]
+			}
+			id, err := sg.addPlayer(name)
+			if err != nil {
+				return nil, lineErr(err.Error())
+			}
+			players[fields[1]] = sg.Players[id]
+			continue
+		case strings.HasPrefix(line, "#lexicon") && len(fields) > 1:
+			sg.Rules.Dictionary = fields[1]
+			continue
+		case !strings.HasPrefix(line, ">"):
+			continue
+		}
+
+		colon := strings.Index(line, ":")
+		if colon < 0 {
+			return nil, lineErr("Move has no player")
+		}
+		p, ok := players[line[1:colon]]
+		if !ok {
+			return nil, lineErr("Unknown player '" + line[1:colon] + "'")
+		}
+
+		fields = strings.Fields(line[colon+1:])
+		if len(fields) < 3 {
+			return nil, lineErr("Move is missing its score")
+		}
+		total, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil {
+			return nil, lineErr("Invalid total score")
+		}
+		score, err := strconv.Atoi(fields[len(fields)-2])
+		if err != nil {
+			return nil, lineErr("Invalid score")
+		}
+		move := fields[:len(fields)-2]
+
+		e := GameEvent{
+			Turn:   sg.TurnCount,
+			Player: p.Name,
+			Score:  score,
+		}
+
+		// Plays are the only moves with a coordinate
+		isPlay := len(move) == 3
+		if len(move) == 2 {
+			_, _, err := parseCoordinate(move[0])
+			isPlay = err == nil
+		}
+
+		if isPlay {
+			if len(move) == 3 {
+				e.Rack, move = parseGCGRack(move[0]), move[1:]
+			}
+			j, err := sg.Board.moveRequest(move[0], move[1])
+			if err != nil {
+				return nil, lineErr(err.Error())
+			}
+			lastPlay, err = sg.Board.evaluatePlay(j)
+			if err != nil {
+				return nil, lineErr(err.Error())
+			}
+			sg.Board.place(lastPlay)
+
+			e.Type = "play"
+			e.Start, e.End = &j.StartPos, &j.EndPos
+			e.Tiles, e.Words = lastPlay.played(), lastPlay.words
+			e.Move = sg.Board.moveNotation(lastPlay.squares)
+			sg.TurnCount++
+		} else {
+			if len(move) == 2 {
+				e.Rack, move = parseGCGRack(move[0]), move[1:]
+			}
+
+			switch action := move[0]; {
+			case action == "-":
+				e.Type = "pass"
+				sg.TurnCount++
+			case action == "--":
+				e.Type = "withdrawn"
+				e.Turn--
+				for _, sc := range lastPlay.squares {
+					sg.Board[sc.Row][sc.Col].Tile = Tile{}
+				}
+				lastPlay = placement{}
+			case action == "(challenge)":
+				e.Type = "challenge"
+				e.Turn--
+			case action == "(time)":
+				e.Type = "penalty"
+			case strings.HasPrefix(action, "(") && strings.HasSuffix(action, ")"):
+				e.Type = "endrack"
+				e.Tiles = parseGCGRack(action[1 : len(action)-1])
+			case strings.HasPrefix(action, "-"):
+				e.Type = "swap"
+				if n, err := strconv.Atoi(action[1:]); err == nil {
+					e.Count = n
+				} else {
+					e.Tiles = parseGCGRack(action[1:])
+					e.Count = len(e.Tiles)
+				}
+				sg.TurnCount++
+			default:
+				return nil, lineErr("Unknown move '" + action + "'")
+			}
+		}
+
+		p.Score = total
+		sg.history.record(e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(sg.Players) == 0 {
+		return nil, errors.New("GCG file has no players")
+	}
+	sg.Locked = true
+	sg.Finished = true
+
+	// Put every tile not on the board in the bag
+	counts := make(map[byte]int, len(tiles))
+	for l, t := range tiles {
+		counts[l] = t.Count
+	}
+	for _, row := range sg.Board {
+		for _, square := range row {
+			if square.Letter == 0 {
+				continue
+			} else if square.Value == 0 {
+				counts[' ']--
+			} else {
+				counts[square.Letter]--
+			}
+		}
+	}
+	sg.TileBag = TileBag{}
+	for l, n := range counts {
+		for i := 0; i < n; i++ {
+			sg.TileBag = append(sg.TileBag, l)
+		}
+	}
+	sg.TileBag.shuffle()
+
+	return sg, nil
+}
+
+// exportGCGHandler handles requests to download a game record in GCG format.
+// The game is given by the game_id query parameter.
+func exportGCGHandler(w http.ResponseWriter, r *http.Request) {
+	gameID, err := uuid.Parse(r.URL.Query().Get("game_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	g, err := getGame(gameID, w)
+	if err != nil {
+		return
+	}
+
+	g.Lock()
+	rules := g.Rules
+	var names []string
+	for _, p := range g.playerList() {
+		names = append(names, p.Name)
+	}
+	g.Unlock()
+
+	if rules.Duplicate || rules.Speed {
+		writeError(w, newError(CodeBadRequest, "Only games played in turns can be exported to GCG"))
+		return
+	}
+
+	// Racks are only written once the game is over
+	events, err := g.visibleHistory(nil)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var b bytes.Buffer
+	err = writeGCG(&b, gameID, rules, names, events)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+gameID.String()+".gcg\"")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b.Bytes())
+}
+
+// importGCGHandler handles requests to load a GCG file into a new game for
+// review. It will respond with the ID of the new game.
+func importGCGHandler(w http.ResponseWriter, r *http.Request) {
+	g, err := readGCG(http.MaxBytesReader(w, r.Body, maxGCGSize))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	serverMu.Lock()
+	server.activeGames[g.ID] = g
+	serverMu.Unlock()
+
+	resp, err := json.Marshal(GeneralGameRequest{GameID: g.ID})
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(resp)
+}
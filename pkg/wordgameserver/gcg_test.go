@@ -0,0 +1,179 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testGCG = `#character-encoding UTF-8
+#player1 alice Alice Smith
+#player2 bob Bob
+>alice: ACHKRTU 8H HACK +26 26
+>bob: ?AEIORZ J6 Ar. +10 10
+>alice: RTUEEIS -RTU +0 26
+>bob: EIOZ - +0 10
+>alice: EEILNS? I8 .I +2 28
+>bob: EIOSTZ L6 ZOE +30 40
+>bob: EIOSTZ -- -30 10
+>alice: EEILNS? (time) -10 18
+>alice: (EIOSTZ) +26 44
+`
+
+// gcgMoves returns the move lines of a GCG file
+func gcgMoves(gcg string) []string {
+	var moves []string
+	for _, line := range strings.Split(gcg, "\n") {
+		if strings.HasPrefix(line, ">") {
+			moves = append(moves, line)
+		}
+	}
+	return moves
+}
+
+func TestGCGRoundTrip(t *testing.T) {
+	sg, err := readGCG(strings.NewReader(testGCG))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	players := sg.playerList()
+	if len(players) != 2 || players[0].Name != "Alice Smith" || players[1].Name != "Bob" {
+		t.Fatalf("Unexpected players %+v, %+v", players[0], players[1])
+	} else if players[0].Score != 44 || players[1].Score != 10 {
+		t.Errorf("Scores are %v and %v, expected 44 and 10", players[0].Score, players[1].Score)
+	} else if !sg.Locked || !sg.Finished || sg.Active {
+		t.Error("Imported game should be locked, finished and not started")
+	}
+
+	if sg.Board[7][7].Letter != 'H' || sg.Board[6][9].Value != 0 || sg.Board[8][8].Letter != 'I' {
+		t.Error("Plays were not placed on the board")
+	} else if sg.Board[5][11].Letter != 0 {
+		t.Error("Withdrawn play was left on the board")
+	} else if len(sg.TileBag) != 100-7 {
+		t.Errorf("Tile bag has %v tiles, expected 93", len(sg.TileBag))
+	}
+
+	var b bytes.Buffer
+	err = writeGCG(&b, sg.ID, sg.Rules, []string{"Alice Smith", "Bob"}, sg.history.list())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(b.String(), "#player1 Alice_Smith Alice Smith\n") {
+		t.Errorf("Player nicknames not written:\n%s", b.String())
+	}
+	expected := strings.Replace(strings.Replace(testGCG, ">alice:", ">Alice_Smith:", -1), ">bob:", ">Bob:", -1)
+	got, want := gcgMoves(b.String()), gcgMoves(expected)
+	if len(got) != len(want) {
+		t.Fatalf("Exported moves:\n%s", b.String())
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Exported %q, expected %q", got[i], want[i])
+		}
+	}
+}
+
+func TestGCGExportFromPlay(t *testing.T) {
+	sg := createScrabbleGame()
+	first, _ := sg.addPlayer("Ann")
+	second, _ := sg.addPlayer("Ben")
+	sg.Players[first].Tiles = []byte("CAT ERS")
+	sg.Players[second].Tiles = []byte("QQXYZVW")
+
+	err := sg.executePlay(GamePlayRequest{
+		PlayerID: first,
+		StartPos: SquareCoordinate{Row: 7, Col: 7},
+		EndPos:   SquareCoordinate{Row: 7, Col: 10},
+		Tiles:    []byte("CAT "),
+		Blanks:   []byte("S"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sg.TurnCount++
+
+	err = sg.executePlay(GamePlayRequest{PlayerID: second, Tiles: []byte("QQ"), Swap: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	err = writeGCG(&b, sg.ID, sg.Rules, []string{"Ann", "Ben"}, sg.history.list())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moves := gcgMoves(b.String())
+	if len(moves) != 2 || moves[0] != ">Ann: CAT?ERS 8H CATs +5 5" || moves[1] != ">Ben: QQXYZVW -QQ +0 0" {
+		t.Errorf("Unexpected moves %q", moves)
+	}
+}
+
+func TestGCGHandlers(t *testing.T) {
+	rr := httptest.NewRecorder()
+	importGCGHandler(rr, httptest.NewRequest("POST", "/game/import", strings.NewReader(testGCG)))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Returned status code %v, expected %v. Error: %v", rr.Code, http.StatusCreated, rr.Body)
+	}
+
+	var j GeneralGameRequest
+	if err := json.NewDecoder(rr.Body).Decode(&j); err != nil {
+		t.Fatal(err)
+	}
+
+	rr = httptest.NewRecorder()
+	exportGCGHandler(rr, httptest.NewRequest("GET", "/game/gcg?game_id="+j.GameID.String(), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", rr.Code, http.StatusOK)
+	} else if moves := gcgMoves(rr.Body.String()); len(moves) != 9 {
+		t.Errorf("Exported %v moves, expected 9", len(moves))
+	}
+
+	rr = httptest.NewRecorder()
+	importGCGHandler(rr, httptest.NewRequest("POST", "/game/import", strings.NewReader(">nobody: ABC 8H ABC +10 10\n")))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Returned status code %v, expected %v", rr.Code, http.StatusBadRequest)
+	}
+}
+
+func TestImportedGameOver(t *testing.T) {
+	sg, err := readGCG(strings.NewReader(testGCG))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverMu.Lock()
+	server.activeGames[sg.ID] = sg
+	serverMu.Unlock()
+
+	playerID := sg.playerList()[0].ID
+	name := "Cy"
+	state := GeneralGameRequest{GameID: sg.ID, PlayerID: &playerID}
+	pass := GamePlayRequest{GameID: sg.ID, PlayerID: playerID, Move: "-"}
+	tests := []struct {
+		path string
+		body interface{}
+	}{
+		{"/game/state", state},
+		{"/game/state?format=text", state},
+		{"/game/play", pass},
+		{"/game/join", GeneralGameRequest{GameID: sg.ID, PlayerName: &name}},
+		{"/v2/games/" + sg.ID.String() + "/moves", pass},
+	}
+
+	for _, test := range tests {
+		rr := serveWithin(t, "POST", test.path, test.body)
+		if rr.Code != http.StatusConflict || responseError(t, rr).Code != CodeGameOver {
+			t.Errorf("%s: status %v, body %v", test.path, rr.Code, rr.Body)
+		}
+	}
+
+	if err := sg.start(); errorCode(err) != CodeGameOver {
+		t.Errorf("Starting an imported game returned %v", err)
+	}
+}
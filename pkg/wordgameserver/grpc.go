@@ -0,0 +1,409 @@
+package wordgameserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/fantashley/wordgame-controller/pkg/wordgamepb"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain is the domain of the ErrorInfo details sent with gRPC errors
+const errorDomain = "wordgame"
+
+// grpcCodes is the gRPC code sent for each HTTP status an error would have.
+// Errors with any other status are invalid arguments.
+var grpcCodes = map[int]codes.Code{
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusMethodNotAllowed:    codes.Unimplemented,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// grpcServer implements the WordGame gRPC service over the same games as the
+// HTTP API
+type grpcServer struct {
+	wordgamepb.UnimplementedWordGameServer
+}
+
+// StartGRPCServer runs the WordGame gRPC service on the address. It can run
+// alongside StartWordGameServer, sharing its games.
+func StartGRPCServer(bindAddr string) error {
+	lis, err := net.Listen("tcp", bindAddr)
+	if err != nil {
+		return err
+	}
+	return NewGRPCServer().Serve(lis)
+}
+
+// NewGRPCServer returns a gRPC server with the WordGame service registered, so
+// it can be served alongside other services
+func NewGRPCServer() *grpc.Server {
+	s := grpc.NewServer()
+	wordgamepb.RegisterWordGameServer(s, grpcServer{})
+	return s
+}
+
+// grpcError converts an error to a gRPC status, with its code as the reason of
+// an ErrorInfo detail
+func grpcError(err error) error {
+	code := errorCode(err)
+	c, ok := grpcCodes[httpStatus(code)]
+	if !ok {
+		c = codes.InvalidArgument
+	}
+
+	s := status.New(c, err.Error())
+	if d, err := s.WithDetails(&errdetails.ErrorInfo{Reason: string(code), Domain: errorDomain}); err == nil {
+		s = d
+	}
+	return s.Err()
+}
+
+// parseID parses a game or player ID from a request
+func parseID(id, name string) (uuid.UUID, error) {
+	u, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, newError(CodeBadRequest, "Invalid "+name+" ID '"+id+"'")
+	}
+	return u, nil
+}
+
+// CreateGame creates a game, adding the creator as its host if they gave a
+// name
+func (grpcServer) CreateGame(ctx context.Context, req *wordgamepb.CreateGameRequest) (*wordgamepb.CreateGameResponse, error) {
+	var j GeneralGameRequest
+	if req.Rules != nil {
+		rules := rulesFromMessage(req.Rules)
+		j.Rules = &rules
+	}
+	if req.PlayerName != "" {
+		j.PlayerName = &req.PlayerName
+	}
+
+	created, err := createGame(j)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	resp := &wordgamepb.CreateGameResponse{GameId: created.GameID.String()}
+	if created.PlayerID != nil {
+		resp.PlayerId = created.PlayerID.String()
+	}
+	return resp, nil
+}
+
+// JoinGame adds a player to a game that has not started
+func (grpcServer) JoinGame(ctx context.Context, req *wordgamepb.JoinGameRequest) (*wordgamepb.JoinGameResponse, error) {
+	gameID, err := parseID(req.GameId, "game")
+	if err != nil {
+		return nil, grpcError(err)
+	} else if req.PlayerName == "" {
+		return nil, grpcError(errPlayerNameRequired)
+	}
+
+	playerID, err := joinGame(gameID, req.PlayerName, nil)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &wordgamepb.JoinGameResponse{PlayerId: playerID.String()}, nil
+}
+
+// StartGame starts a game at the request of its host
+func (grpcServer) StartGame(ctx context.Context, req *wordgamepb.StartGameRequest) (*wordgamepb.StartGameResponse, error) {
+	gameID, err := parseID(req.GameId, "game")
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	playerID, err := parseID(req.PlayerId, "player")
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	if err := hostStartGame(gameID, &playerID); err != nil {
+		return nil, grpcError(err)
+	}
+	return &wordgamepb.StartGameResponse{}, nil
+}
+
+// GetGame gets a game as seen by a player, or by spectators if no player ID is
+// given
+func (grpcServer) GetGame(ctx context.Context, req *wordgamepb.GetGameRequest) (*wordgamepb.Game, error) {
+	gameID, err := parseID(req.GameId, "game")
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	var playerID *uuid.UUID
+	if req.PlayerId != "" {
+		id, err := parseID(req.PlayerId, "player")
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		playerID = &id
+	}
+
+	res, err := gameResource(gameID, playerID)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	game := gameMessage(res)
+	if playerID == nil && res.Started {
+		g, err := findGame(gameID)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		if u := g.spectators.latest(); u != nil {
+			spectatorMessage(game, *u)
+		}
+	}
+	return game, nil
+}
+
+// Play plays, swaps or passes, and returns the game as seen by the player
+// afterwards. A move sent to the game before the context is done is still
+// made, even if the client has stopped waiting for it.
+func (grpcServer) Play(ctx context.Context, req *wordgamepb.PlayRequest) (*wordgamepb.Game, error) {
+	gameID, err := parseID(req.GameId, "game")
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	playerID, err := parseID(req.PlayerId, "player")
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	// The state controller always sends its reply, so the request finishes in
+	// its own goroutine if the context is done first
+	type result struct {
+		state GameStateResponse
+		err   error
+	}
+	done := make(chan result, 1)
+	j := moveRequest(req.Move)
+	j.GameID, j.PlayerID, j.Play = gameID, playerID, true
+	go func() {
+		state, err := requestGame(j)
+		done <- result{state, err}
+	}()
+
+	var state GameStateResponse
+	select {
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case r := <-done:
+		if r.err != nil {
+			return nil, grpcError(r.err)
+		}
+		state = r.state
+	}
+
+	res, err := gameResource(gameID, nil)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	res.State = &state
+	return gameMessage(res), nil
+}
+
+// WatchGame streams the game as seen by spectators until the client cancels or
+// the game is over. Updates revealing racks are not sent.
+func (grpcServer) WatchGame(req *wordgamepb.WatchGameRequest, stream wordgamepb.WordGame_WatchGameServer) error {
+	gameID, err := parseID(req.GameId, "game")
+	if err != nil {
+		return grpcError(err)
+	}
+
+	g, err := findGame(gameID)
+	if err != nil {
+		return grpcError(err)
+	}
+
+	// Games that finished without being played here will never send an update
+	g.Lock()
+	reviewed := g.Finished && !g.Active
+	g.Unlock()
+	if reviewed {
+		return grpcError(errGameOver)
+	}
+
+	updates := g.spectators.subscribe(nil)
+	defer g.spectators.unsubscribe(updates)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e := <-updates:
+			u, ok := e.Data.(SpectatorUpdate)
+			if !ok || u.Racks != nil {
+				continue
+			}
+
+			// Rules are fixed once the game starts
+			res, err := gameResource(gameID, nil)
+			if err != nil {
+				return grpcError(err)
+			}
+			game := gameMessage(res)
+			spectatorMessage(game, u)
+
+			if err := stream.Send(game); err != nil {
+				return err
+			} else if u.Winner != "" {
+				return nil
+			}
+		}
+	}
+}
+
+// moveRequest converts a move to the request a player sends to make it
+func moveRequest(m *wordgamepb.Move) GamePlayRequest {
+	if m == nil {
+		return GamePlayRequest{}
+	}
+
+	j := GamePlayRequest{
+		Move: m.Notation,
+		Swap: m.Swap,
+	}
+	if m.Tiles != "" {
+		j.Tiles = []byte(m.Tiles)
+	}
+	if m.Blanks != "" {
+		j.Blanks = []byte(m.Blanks)
+	}
+	if m.Start != nil {
+		j.StartPos = SquareCoordinate{Row: int(m.Start.Row), Col: int(m.Start.Col)}
+	}
+	if m.End != nil {
+		j.EndPos = SquareCoordinate{Row: int(m.End.Row), Col: int(m.End.Col)}
+	}
+	return j
+}
+
+// gameMessage converts a game to its message. Players are only named until
+// the game has started, unless the state is included.
+func gameMessage(res GameResource) *wordgamepb.Game {
+	game := &wordgamepb.Game{
+		GameId:  res.GameID.String(),
+		Rules:   rulesMessage(res.Rules),
+		Started: res.Started,
+		Locked:  res.Locked,
+		Players: make([]*wordgamepb.Player, len(res.Players)),
+	}
+	for i, name := range res.Players {
+		game.Players[i] = &wordgamepb.Player{Name: name, Number: int32(i)}
+	}
+
+	if s := res.State; s != nil {
+		game.Players = playerMessages(s.Players)
+		game.Board = boardMessage(&s.Board)
+		game.Turn = int32(s.PlayerTurn)
+		game.TilesRemaining = int32(s.TilesRemaining)
+		game.Rack = string(s.PlayerTiles)
+		game.TeammateRack = string(s.TeammateTiles)
+		game.Winner = s.Winner
+	}
+	return game
+}
+
+// spectatorMessage fills in a game's message from an update sent to spectators
+func spectatorMessage(game *wordgamepb.Game, u SpectatorUpdate) {
+	game.Players = playerMessages(u.Players)
+	game.Board = boardMessage(&u.Board)
+	game.Turn = int32(u.PlayerTurn)
+	game.TilesRemaining = int32(u.TilesRemaining)
+	game.Winner = u.Winner
+}
+
+// playerMessages converts players to their messages
+func playerMessages(players []*Player) []*wordgamepb.Player {
+	msgs := make([]*wordgamepb.Player, len(players))
+	for i, p := range players {
+		msgs[i] = &wordgamepb.Player{
+			Name:      p.Name,
+			Number:    int32(p.Number),
+			Score:     int32(p.Score),
+			Forfeited: p.Forfeited,
+			Team:      int32(p.Team),
+		}
+		if p.TimeRemaining != 0 {
+			msgs[i].TimeRemaining = durationpb.New(p.TimeRemaining)
+		}
+	}
+	return msgs
+}
+
+// boardMessage converts a board to its message, row by row
+func boardMessage(b *ScrabbleBoard) *wordgamepb.Board {
+	board := &wordgamepb.Board{
+		Squares: make([]*wordgamepb.Square, 0, rowCount*columnCount),
+	}
+	for _, row := range b {
+		for _, sq := range row {
+			s := &wordgamepb.Square{Type: sq.SquareType, Value: int32(sq.Value)}
+			if sq.Letter != 0 {
+				s.Letter = string(sq.Letter)
+			}
+			board.Squares = append(board.Squares, s)
+		}
+	}
+	return board
+}
+
+// rulesMessage converts rules to their message
+func rulesMessage(r GameRules) *wordgamepb.Rules {
+	return &wordgamepb.Rules{
+		Dictionary:                r.Dictionary,
+		Clabbers:                  r.Clabbers,
+		TurnOrder:                 r.TurnOrder,
+		DisableTileTracking:       r.DisableTileTracking,
+		ClockMinutes:              int32(r.ClockMinutes),
+		ForfeitOnTime:             r.ForfeitOnTime,
+		TurnDeadlineHours:         int32(r.TurnDeadlineHours),
+		ForfeitOnDeadline:         r.ForfeitOnDeadline,
+		SpectatorRackDelaySeconds: int32(r.SpectatorRackDelaySeconds),
+		Duplicate:                 r.Duplicate,
+		DuplicateRoundSeconds:     int32(r.DuplicateRoundSeconds),
+		Speed:                     r.Speed,
+		Teams:                     r.Teams,
+		TeamRackSharing:           r.TeamRackSharing,
+		SpectatorChat:             r.SpectatorChat,
+		ChatFilter:                r.ChatFilter,
+	}
+}
+
+// rulesFromMessage converts a rules message to rules
+func rulesFromMessage(r *wordgamepb.Rules) GameRules {
+	return GameRules{
+		Dictionary:                r.Dictionary,
+		Clabbers:                  r.Clabbers,
+		TurnOrder:                 r.TurnOrder,
+		DisableTileTracking:       r.DisableTileTracking,
+		ClockMinutes:              int(r.ClockMinutes),
+		ForfeitOnTime:             r.ForfeitOnTime,
+		TurnDeadlineHours:         int(r.TurnDeadlineHours),
+		ForfeitOnDeadline:         r.ForfeitOnDeadline,
+		SpectatorRackDelaySeconds: int(r.SpectatorRackDelaySeconds),
+		Duplicate:                 r.Duplicate,
+		DuplicateRoundSeconds:     int(r.DuplicateRoundSeconds),
+		Speed:                     r.Speed,
+		Teams:                     r.Teams,
+		TeamRackSharing:           r.TeamRackSharing,
+		SpectatorChat:             r.SpectatorChat,
+		ChatFilter:                r.ChatFilter,
+	}
+}
@@ -0,0 +1,263 @@
+package wordgameserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fantashley/wordgame-controller/pkg/wordgamepb"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// grpcClient serves the gRPC service in memory and connects a client to it
+func grpcClient(t *testing.T) wordgamepb.WordGameClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := NewGRPCServer()
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return wordgamepb.NewWordGameClient(conn)
+}
+
+// checkGRPCError checks an error's gRPC code and the reason in its details
+func checkGRPCError(t *testing.T, err error, c codes.Code, code ErrorCode) {
+	t.Helper()
+
+	s := status.Convert(err)
+	if s.Code() != c {
+		t.Errorf("Error %v has code %v, expected %v", err, s.Code(), c)
+	}
+	for _, d := range s.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if info.Reason != string(code) {
+				t.Errorf("Error %v has reason %v, expected %v", err, info.Reason, code)
+			}
+			return
+		}
+	}
+	t.Errorf("Error %v has no reason", err)
+}
+
+func TestGRPCGame(t *testing.T) {
+	c := grpcClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	created, err := c.CreateGame(ctx, &wordgamepb.CreateGameRequest{
+		PlayerName: "Ann",
+		Rules:      &wordgamepb.Rules{ChatFilter: []string{"darn"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined, err := c.JoinGame(ctx, &wordgamepb.JoinGameRequest{GameId: created.GameId, PlayerName: "Ben"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := c.GetGame(ctx, &wordgamepb.GetGameRequest{GameId: created.GameId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.Started || len(game.Players) != 2 || game.Players[1].Name != "Ben" || game.Rules.ChatFilter[0] != "darn" {
+		t.Errorf("Game before starting is %v", game)
+	}
+
+	watch, err := c.WatchGame(ctx, &wordgamepb.WatchGameRequest{GameId: created.GameId})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.StartGame(ctx, &wordgamepb.StartGameRequest{GameId: created.GameId, PlayerId: joined.PlayerId})
+	checkGRPCError(t, err, codes.PermissionDenied, CodeNotHost)
+	if _, err := c.StartGame(ctx, &wordgamepb.StartGameRequest{GameId: created.GameId, PlayerId: created.PlayerId}); err != nil {
+		t.Fatal(err)
+	}
+
+	update, err := watch.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update.Started || len(update.Board.Squares) != rowCount*columnCount || update.Rack != "" {
+		t.Errorf("Update after starting is %v", update)
+	}
+
+	// Whoever is first plays their first two tiles from the star
+	ids := map[string]string{"Ann": created.PlayerId, "Ben": joined.PlayerId}
+	first := ids[update.Players[update.Turn].Name]
+	game, err = c.GetGame(ctx, &wordgamepb.GetGameRequest{GameId: created.GameId, PlayerId: first})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(game.Rack) != 7 {
+		t.Fatalf("Rack is %q", game.Rack)
+	}
+
+	move := &wordgamepb.Move{
+		Start: &wordgamepb.Coordinate{Row: 7, Col: 7},
+		End:   &wordgamepb.Coordinate{Row: 7, Col: 8},
+		Tiles: game.Rack[:2],
+	}
+	for _, l := range move.Tiles {
+		if l == ' ' {
+			move.Blanks += "E"
+		}
+	}
+	game, err = c.Play(ctx, &wordgamepb.PlayRequest{GameId: created.GameId, PlayerId: first, Move: move})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if center := game.Board.Squares[7*columnCount+7]; center.Letter == "" || center.Type != "star" {
+		t.Errorf("Center square is %v", center)
+	}
+
+	update, err = watch.Recv()
+	if err != nil {
+		t.Fatal(err)
+	} else if update.Board.Squares[7*columnCount+8].Letter == "" {
+		t.Error("Update does not include the play")
+	}
+
+	_, err = c.Play(ctx, &wordgamepb.PlayRequest{GameId: created.GameId, PlayerId: first, Move: &wordgamepb.Move{Notation: "-"}})
+	checkGRPCError(t, err, codes.FailedPrecondition, CodeNotYourTurn)
+
+	_, err = c.GetGame(ctx, &wordgamepb.GetGameRequest{GameId: "not-an-id"})
+	checkGRPCError(t, err, codes.InvalidArgument, CodeBadRequest)
+	_, err = c.JoinGame(ctx, &wordgamepb.JoinGameRequest{GameId: uuid.New().String(), PlayerName: "Cy"})
+	checkGRPCError(t, err, codes.NotFound, CodeGameNotFound)
+}
+
+func TestGRPCPlayBeforeStart(t *testing.T) {
+	c := grpcClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	created, err := c.CreateGame(ctx, &wordgamepb.CreateGameRequest{PlayerName: "Ann"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Play(ctx, &wordgamepb.PlayRequest{GameId: created.GameId, PlayerId: created.PlayerId, Move: &wordgamepb.Move{Notation: "-"}})
+	checkGRPCError(t, err, codes.FailedPrecondition, CodeGameNotStarted)
+}
+
+func TestGRPCPlayErrors(t *testing.T) {
+	c := grpcClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	created, err := c.CreateGame(ctx, &wordgamepb.CreateGameRequest{PlayerName: "Ann"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined, err := c.JoinGame(ctx, &wordgamepb.JoinGameRequest{GameId: created.GameId, PlayerName: "Ben"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.StartGame(ctx, &wordgamepb.StartGameRequest{GameId: created.GameId, PlayerId: created.PlayerId}); err != nil {
+		t.Fatal(err)
+	}
+
+	game, err := c.GetGame(ctx, &wordgamepb.GetGameRequest{GameId: created.GameId, PlayerId: created.PlayerId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := map[string]string{"Ann": created.PlayerId, "Ben": joined.PlayerId}[game.Players[game.Turn].Name]
+	if game, err = c.GetGame(ctx, &wordgamepb.GetGameRequest{GameId: created.GameId, PlayerId: first}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first play must cover the star
+	_, err = c.Play(ctx, &wordgamepb.PlayRequest{GameId: created.GameId, PlayerId: first, Move: &wordgamepb.Move{
+		Start:  &wordgamepb.Coordinate{Row: 0, Col: 0},
+		End:    &wordgamepb.Coordinate{Row: 0, Col: 1},
+		Tiles:  game.Rack[:2],
+		Blanks: strings.Repeat("A", strings.Count(game.Rack[:2], " ")),
+	}})
+	checkGRPCError(t, err, codes.InvalidArgument, CodeInvalidPlacement)
+
+	cancelled, cancelNow := context.WithCancel(ctx)
+	cancelNow()
+	_, err = c.Play(cancelled, &wordgamepb.PlayRequest{GameId: created.GameId, PlayerId: first, Move: &wordgamepb.Move{Notation: "-"}})
+	if status.Code(err) != codes.Canceled {
+		t.Errorf("Play with a cancelled context returned %v", err)
+	}
+
+	// The player is still up, since neither move was made
+	if _, err := c.Play(ctx, &wordgamepb.PlayRequest{GameId: created.GameId, PlayerId: first, Move: &wordgamepb.Move{Notation: "-"}}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGRPCWatchGameOver(t *testing.T) {
+	c := grpcClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	newGame := createScrabbleGame()
+	newGame.Rules.Speed = true
+	playerID, _ := newGame.addPlayer("ashley1")
+	newGame.addPlayer("ashley2")
+
+	// Too few tiles to deal after the first peel, so it wins the game
+	newGame.TileBag = newGame.TileBag[:2*speedStartTiles+1]
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	watch, err := c.WatchGame(ctx, &wordgamepb.WatchGameRequest{GameId: newGame.ID.String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+	if update, err := watch.Recv(); err != nil {
+		t.Fatal(err)
+	} else if update.Winner != "" {
+		t.Fatalf("Game is over as it starts: %v", update)
+	}
+
+	s, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = newGame.request(GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: playerID,
+		Grid:     lineGrid(s.PlayerTiles),
+		Peel:     true,
+		Play:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update, err := watch.Recv()
+	if err != nil {
+		t.Fatal(err)
+	} else if update.Winner != "ashley1" {
+		t.Errorf("Last update is %v, expected ashley1 to win", update)
+	}
+	if _, err := watch.Recv(); err != io.EOF {
+		t.Errorf("Stream did not end with the game: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GameEvent is an entry in a game's history
+type GameEvent struct {
+	Type   string            `json:"type"`             // such as draw, play, swap, pass, penalty, forfeit, endrack or chat
+	Turn   int               `json:"turn"`             // turn count when the event happened
+	Player string            `json:"player,omitempty"` // name of the player involved, if any
+	Rack   string            `json:"rack,omitempty"`   // player's rack before their turn, hidden from others until the game is over
+	Start  *SquareCoordinate `json:"start,omitempty"`  // first square of a play
+	End    *SquareCoordinate `json:"end,omitempty"`    // last square of a play
+	Tiles  string            `json:"tiles,omitempty"`  // tiles played with blanks lowercase, or tiles swapped
+	Move   string            `json:"move,omitempty"`   // play in standard notation, such as 8H WORD
+	Words  []string          `json:"words,omitempty"`  // words formed by a play, main word first
+	Score  int               `json:"score,omitempty"`  // points scored by a play, or lost to a penalty
+	Count  int               `json:"count,omitempty"`  // number of tiles swapped
+	Draws  []TileDraw        `json:"draws,omitempty"`  // tiles drawn to decide turn order
+	Text   string            `json:"text,omitempty"`   // text of a chat message
+	Time   time.Time         `json:"time"`
+
+	owner uuid.UUID // player whose rack is recorded
+	team  int       // team that can see a team-only chat message
+}
+
+// TileDraw is a tile drawn by a player to decide who goes first
+type TileDraw struct {
+	Round  int    `json:"round"`  // draws are repeated between tied players
+	Player string `json:"player"` // name of the player who drew
+	Letter string `json:"letter"` // letter drawn, a space for a blank
+}
+
+// gameHistory records the events of a game in order
+type gameHistory struct {
+	sync.Mutex
+	events []GameEvent
+}
+
+// newGameHistory creates an empty history
+func newGameHistory() *gameHistory {
+	return &gameHistory{
+		events: make([]GameEvent, 0),
+	}
+}
+
+// record adds an event to the history
+func (h *gameHistory) record(e GameEvent) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	h.Lock()
+	h.events = append(h.events, e)
+	h.Unlock()
+}
+
+// list returns a copy of the events recorded so far
+func (h *gameHistory) list() []GameEvent {
+	h.Lock()
+	defer h.Unlock()
+
+	events := make([]GameEvent, len(h.events))
+	copy(events, h.events)
+	return events
+}
+
+// visibleHistory returns the game's events as seen by the player, or by
+// spectators if no player is given. Racks are hidden until the game is over,
+// except for the player's own. Chat messages are only included for those who
+// could read them in the chat.
+func (sg *ScrabbleGame) visibleHistory(playerID *uuid.UUID) ([]GameEvent, error) {
+	sg.Lock()
+	finished, spectatorChat := sg.Finished, sg.Rules.SpectatorChat
+	isPlayer, team := false, 0
+	if playerID != nil {
+		var p *Player
+		if p, isPlayer = sg.Players[*playerID]; isPlayer {
+			team = p.Team
+		}
+	}
+	sg.Unlock()
+
+	if playerID != nil && !isPlayer {
+		return nil, errPlayerNotFound
+	}
+
+	readsChat := spectatorChat
+	if playerID != nil {
+		readsChat = !sg.chat.isMuted(*playerID)
+	}
+
+	all := sg.history.list()
+	events := make([]GameEvent, 0, len(all))
+	for _, e := range all {
+		if e.Type == "chat" && (!readsChat || (e.team != 0 && e.team != team)) {
+			continue
+		}
+		if !finished && (playerID == nil || e.owner != *playerID) {
+			e.Rack = ""
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// gameHistoryHandler handles requests for the history of a game. It will
+// respond with a list of GameEvent. Players who give their player_id see their
+// own racks before the game is over.
+func gameHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	var j GeneralGameRequest
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	g, err := getGame(j.GameID, w)
+	if err != nil {
+		return
+	}
+
+	events, err := g.visibleHistory(j.PlayerID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := json.Marshal(events)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
@@ -0,0 +1,97 @@
+package wordgameserver
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHistoryHidesRacks(t *testing.T) {
+	newGame := createScrabbleGame()
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Whoever is first swaps a tile, which records their rack
+	s, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := playerIDs[s.PlayerTurn], playerIDs[1-s.PlayerTurn]
+	if s, err = newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: first}); err != nil {
+		t.Fatal(err)
+	}
+	rack := string(s.PlayerTiles)
+	_, err = newGame.request(GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: first,
+		Tiles:    s.PlayerTiles[:1],
+		Swap:     true,
+		Play:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	racks := func(events []GameEvent) []string {
+		var r []string
+		for _, e := range events {
+			if e.Rack != "" {
+				r = append(r, e.Rack)
+			}
+		}
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     interface{}
+		expected []string
+	}{
+		{"History for spectators", "POST", "/game/history", GeneralGameRequest{GameID: newGame.ID}, nil},
+		{"History for the other player", "POST", "/game/history", GeneralGameRequest{GameID: newGame.ID, PlayerID: &second}, nil},
+		{"History for the swapper", "POST", "/game/history", GeneralGameRequest{GameID: newGame.ID, PlayerID: &first}, []string{rack}},
+		{"Moves for spectators", "GET", "/v2/games/" + newGame.ID.String() + "/moves", nil, nil},
+		{"Moves for the swapper", "GET", "/v2/games/" + newGame.ID.String() + "/moves?player_id=" + first.String(), nil, []string{rack}},
+	}
+
+	for _, test := range tests {
+		var events []GameEvent
+		decodeResponse(t, serveWithin(t, test.method, test.path, test.body), http.StatusOK, &events)
+		if got := racks(events); strings.Join(got, ",") != strings.Join(test.expected, ",") {
+			t.Errorf("%s: racks are %q, expected %q", test.name, got, test.expected)
+		}
+	}
+
+	rr := serveWithin(t, "GET", "/game/gcg?game_id="+newGame.ID.String(), nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", rr.Code, http.StatusOK)
+	} else if moves := gcgMoves(rr.Body.String()); len(moves) != 1 || strings.Contains(moves[0], gcgRack(rack)) {
+		t.Errorf("GCG of a live game shows the rack: %q", moves)
+	}
+
+	// Everyone sees the racks once the game is over
+	newGame.Lock()
+	newGame.Finished = true
+	newGame.Unlock()
+
+	events, err := newGame.visibleHistory(nil)
+	if err != nil {
+		t.Fatal(err)
+	} else if got := racks(events); len(got) != 1 || got[0] != rack {
+		t.Errorf("Racks after the game are %q, expected %q", got, rack)
+	}
+}
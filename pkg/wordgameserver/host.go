@@ -0,0 +1,171 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// errNotHost is returned when a player other than the host tries to manage
+// the game
+var errNotHost = newError(CodeNotHost, "Only the host can do that")
+
+// isHost reports whether the player is the game's host
+func (sg *ScrabbleGame) isHost(playerID *uuid.UUID) bool {
+	return playerID != nil && sg.HostID != uuid.Nil && *playerID == sg.HostID
+}
+
+// removePlayer takes a player out of a game that has not started. The
+// remaining players are renumbered so that their numbers stay dense, and the
+// host passes to the earliest remaining player if the host left.
+func (sg *ScrabbleGame) removePlayer(playerID uuid.UUID) error {
+	if sg.Active {
+		return errGameStarted
+	}
+
+	p, ok := sg.Players[playerID]
+	if !ok {
+		return errPlayerNotFound
+	}
+
+	delete(sg.Players, playerID)
+	for _, other := range sg.Players {
+		if other.Number > p.Number {
+			other.Number--
+		}
+	}
+
+	if sg.HostID == playerID {
+		sg.HostID = uuid.Nil
+		for _, other := range sg.Players {
+			if other.Number == 0 {
+				sg.HostID = other.ID
+			}
+		}
+	}
+
+	return nil
+}
+
+// hostRequestHelper decodes a request that changes a game before it starts,
+// and looks up the game. The caller must unlock the game if it is returned.
+func hostRequestHelper(w http.ResponseWriter, r *http.Request) (GeneralGameRequest, *ScrabbleGame, bool) {
+	var j GeneralGameRequest
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return j, nil, false
+	} else if j.PlayerID == nil {
+		writeError(w, errPlayerIDRequired)
+		return j, nil, false
+	}
+
+	g, err := getGame(j.GameID, w)
+	if err != nil {
+		return j, nil, false
+	}
+
+	g.Lock()
+	if g.Active {
+		g.Unlock()
+		writeError(w, errGameStarted)
+		return j, nil, false
+	}
+
+	return j, g, true
+}
+
+// leaveGameHandler handles requests from players to leave a game before it
+// starts
+func leaveGameHandler(w http.ResponseWriter, r *http.Request) {
+	j, g, ok := hostRequestHelper(w, r)
+	if !ok {
+		return
+	}
+	defer g.Unlock()
+
+	if err := g.removePlayer(*j.PlayerID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+// kickPlayerHandler handles requests from the host to remove the player with
+// the target ID from the game before it starts
+func kickPlayerHandler(w http.ResponseWriter, r *http.Request) {
+	j, g, ok := hostRequestHelper(w, r)
+	if !ok {
+		return
+	}
+	defer g.Unlock()
+
+	if !g.isHost(j.PlayerID) {
+		writeError(w, errNotHost)
+		return
+	} else if j.TargetID == nil {
+		writeError(w, newError(CodeBadRequest, "Target ID is required"))
+		return
+	} else if *j.TargetID == *j.PlayerID {
+		writeError(w, newError(CodeBadRequest, "Host cannot kick themselves"))
+		return
+	}
+
+	if err := g.removePlayer(*j.TargetID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+// lockGameHandler handles requests from the host to lock or unlock the game.
+// Locked games cannot be joined and are not listed in the lobby.
+func lockGameHandler(w http.ResponseWriter, r *http.Request) {
+	j, g, ok := hostRequestHelper(w, r)
+	if !ok {
+		return
+	}
+	defer g.Unlock()
+
+	if !g.isHost(j.PlayerID) {
+		writeError(w, errNotHost)
+		return
+	} else if j.Locked == nil {
+		writeError(w, newError(CodeBadRequest, "Locked is required"))
+		return
+	}
+
+	g.Locked = *j.Locked
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
+
+// gameSettingsHandler handles requests from the host to replace the game's
+// rules before it starts
+func gameSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	j, g, ok := hostRequestHelper(w, r)
+	if !ok {
+		return
+	}
+	defer g.Unlock()
+
+	if !g.isHost(j.PlayerID) {
+		writeError(w, errNotHost)
+		return
+	} else if j.Rules == nil {
+		writeError(w, newError(CodeBadRequest, "Rules are required"))
+		return
+	}
+
+	g.Rules = *j.Rules
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
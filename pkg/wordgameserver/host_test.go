@@ -0,0 +1,145 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHostControls(t *testing.T) {
+	newGame := createScrabbleGame()
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	playerIDs := make([]uuid.UUID, 4)
+	for i, name := range []string{"ashley1", "ashley2", "ashley3", "ashley4"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	if newGame.HostID != playerIDs[0] {
+		t.Fatal("First player to join should host the game")
+	}
+
+	// Only the host can kick players
+	rr := hostRequest(t, kickPlayerHandler, GeneralGameRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerIDs[1],
+		TargetID: &playerIDs[2],
+	})
+	if c := rr.Code; c != http.StatusForbidden {
+		t.Fatalf("Returned status code %v, expected %v", c, http.StatusForbidden)
+	}
+
+	rr = hostRequest(t, kickPlayerHandler, GeneralGameRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerIDs[0],
+		TargetID: &playerIDs[1],
+	})
+	if c := rr.Code; c != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v. Error: %v", c, http.StatusOK, rr.Body)
+	}
+
+	// Host leaves, so the host passes on and players are renumbered
+	rr = hostRequest(t, leaveGameHandler, GeneralGameRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerIDs[0],
+	})
+	if c := rr.Code; c != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v. Error: %v", c, http.StatusOK, rr.Body)
+	}
+
+	if len(newGame.Players) != 2 {
+		t.Fatalf("Expected 2 players left, got %v", len(newGame.Players))
+	} else if newGame.Players[playerIDs[2]].Number != 0 || newGame.Players[playerIDs[3]].Number != 1 {
+		t.Fatal("Remaining players were not renumbered")
+	} else if newGame.HostID != playerIDs[2] {
+		t.Fatal("Host did not pass to earliest remaining player")
+	}
+
+	for i, p := range newGame.playerList() {
+		if p == nil || p.Number != i {
+			t.Fatal("Player list is not dense after players left")
+		}
+	}
+
+	// Locked games cannot be joined
+	locked := true
+	rr = hostRequest(t, lockGameHandler, GeneralGameRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerIDs[2],
+		Locked:   &locked,
+	})
+	if c := rr.Code; c != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v. Error: %v", c, http.StatusOK, rr.Body)
+	}
+
+	if _, err := newGame.addPlayer("ashley5"); err == nil {
+		t.Fatal("Player should not be able to join locked game")
+	}
+
+	// Host can change the rules
+	rr = hostRequest(t, gameSettingsHandler, GeneralGameRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerIDs[2],
+		Rules:    &GameRules{ClockMinutes: 25},
+	})
+	if c := rr.Code; c != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v. Error: %v", c, http.StatusOK, rr.Body)
+	} else if newGame.Rules.ClockMinutes != 25 {
+		t.Fatal("Game rules were not changed")
+	}
+}
+
+func TestCreateGameHandlerHost(t *testing.T) {
+	name := "ashley1"
+	payload, err := json.Marshal(GeneralGameRequest{PlayerName: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("POST", "/game/create", bytes.NewBuffer(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(createGameHandler).ServeHTTP(rr, req)
+
+	var j GeneralGameRequest
+	err = json.NewDecoder(rr.Body).Decode(&j)
+	if err != nil {
+		t.Fatal("Response was not in correct format")
+	} else if j.PlayerID == nil {
+		t.Fatal("Creator was not given a player ID")
+	}
+
+	g, err := getGame(j.GameID, rr)
+	if err != nil {
+		t.Fatal(err)
+	} else if g.HostID != *j.PlayerID {
+		t.Fatal("Creator is not the host")
+	}
+}
+
+func hostRequest(t *testing.T, handler http.HandlerFunc, j GeneralGameRequest) *httptest.ResponseRecorder {
+	payload, err := json.Marshal(j)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("POST", "/game", bytes.NewBuffer(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
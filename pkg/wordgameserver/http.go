@@ -2,7 +2,7 @@ package wordgameserver
 
 import (
 	"encoding/json"
-	"errors"
+	"io"
 	"net/http"
 	"sync"
 
@@ -20,18 +20,27 @@ type GeneralGameRequest struct {
 	GameID     uuid.UUID  `json:"game_id"`
 	PlayerID   *uuid.UUID `json:"player_id,omitempty"`
 	PlayerName *string    `json:"player_name,omitempty"`
+	Rules      *GameRules `json:"rules,omitempty"`
+	TargetID   *uuid.UUID `json:"target_id,omitempty"` // player acted on by the host
+	Locked     *bool      `json:"locked,omitempty"`
+	Team       *int       `json:"team,omitempty"` // team chosen when joining a team game, any team with room if not set
 }
 
 // GameStateResponse is the format of the response sent to clients when they
 // request the current game state
 type GameStateResponse struct {
-	GameID      uuid.UUID     `json:"game_id"`
-	PlayerID    uuid.UUID     `json:"-"`
-	Players     []*Player     `json:"players"`
-	Board       ScrabbleBoard `json:"board"`
-	PlayerTurn  int           `json:"turn"`
-	PlayerTiles []byte        `json:"tiles"`
-	Error       error         `json:"-"`
+	GameID         uuid.UUID     `json:"game_id"`
+	PlayerID       uuid.UUID     `json:"-"`
+	Players        []*Player     `json:"players"`
+	Board          ScrabbleBoard `json:"board"`
+	PlayerTurn     int           `json:"turn"`
+	PlayerTiles    []byte        `json:"tiles"`
+	TilesRemaining int           `json:"tiles_remaining"`
+	Teams          []TeamScore   `json:"teams,omitempty"`
+	TeammateTiles  []byte        `json:"teammate_tiles,omitempty"`
+	Grid           []GridTile    `json:"grid,omitempty"`
+	Winner         string        `json:"winner,omitempty"`
+	Error          error         `json:"-"`
 }
 
 // GamePlayRequest is the format of the request a client sends when they would
@@ -43,7 +52,10 @@ type GamePlayRequest struct {
 	EndPos   SquareCoordinate `json:"end_pos"`
 	Tiles    []byte           `json:"tiles"`
 	Blanks   []byte           `json:"blanks,omitempty"`
+	Move     string           `json:"move,omitempty"` // play in standard notation, used instead of positions and tiles
 	Swap     bool             `json:"swap"`
+	Peel     bool             `json:"peel,omitempty"`
+	Grid     []GridTile       `json:"grid,omitempty"`
 	Play     bool             `json:"-"`
 }
 
@@ -57,31 +69,85 @@ var (
 // StartWordGameServer is the function that is run to start the Word Game HTTP
 // server
 func StartWordGameServer(bindAddr string) error {
+	return http.ListenAndServe(bindAddr, Handler())
+}
+
+// Handler returns the router for the Word Game HTTP API and browser client, so
+// it can be served alongside other handlers
+func Handler() http.Handler {
+	return newRouter()
+}
+
+// newRouter registers every endpoint. Each one must be described in the
+// OpenAPI document.
+func newRouter() *mux.Router {
 	r := mux.NewRouter()
 	r.HandleFunc("/game/create", createGameHandler)
 	r.HandleFunc("/game/join", joinGameHandler)
 	r.HandleFunc("/game/start", startGameHandler)
+	r.HandleFunc("/game/leave", leaveGameHandler)
+	r.HandleFunc("/game/kick", kickPlayerHandler)
+	r.HandleFunc("/game/lock", lockGameHandler)
+	r.HandleFunc("/game/settings", gameSettingsHandler)
 	r.HandleFunc("/game/state", gameStateHandler)
-
-	return http.ListenAndServe(bindAddr, r)
+	r.HandleFunc("/game/play", gamePlayHandler)
+	r.HandleFunc("/game/unseen", unseenTilesHandler)
+	r.HandleFunc("/game/history", gameHistoryHandler)
+	r.HandleFunc("/game/gcg", exportGCGHandler)
+	r.HandleFunc("/game/import", importGCGHandler)
+	r.HandleFunc("/game/board.svg", boardSVGHandler)
+	r.HandleFunc("/game/board.png", boardPNGHandler)
+	r.HandleFunc("/game/watch", watchGameHandler)
+	r.HandleFunc("/game/chat", readChatHandler)
+	r.HandleFunc("/game/chat/post", postChatHandler)
+	r.HandleFunc("/game/chat/mute", muteChatHandler)
+	r.HandleFunc("/player/inbox", inboxHandler)
+	r.HandleFunc("/lobby/games", lobbyHandler)
+	r.HandleFunc("/lobby/match", matchHandler)
+	r.HandleFunc("/puzzle/daily", puzzleHandler)
+	r.HandleFunc("/puzzle/answer", puzzleAnswerHandler)
+	r.HandleFunc("/words/check", checkWordHandler)
+	r.HandleFunc("/words/anagrams", anagramHandler)
+	r.HandleFunc("/words/pattern", patternHandler)
+	r.HandleFunc("/words/hooks", hooksHandler)
+	r.HandleFunc("/study/quiz", studyQuizHandler)
+	r.HandleFunc("/study/answer", studyAnswerHandler)
+	r.HandleFunc("/study/cardbox", cardboxHandler)
+	r.HandleFunc("/openapi.json", openAPIHandler)
+
+	// Resource-oriented API, which only accepts the methods it documents
+	registerV2(r.PathPrefix("/v2").Subrouter())
+
+	// Everything else is the browser client
+	r.PathPrefix("/").Handler(webHandler())
+
+	return r
 }
 
 // createGameHandler handles API requests for creating a new Scrabble game
-// instance
+// instance. The request body is optional and may contain the game's rules. If
+// it contains a player name, the creator joins the game as its host and their
+// player ID is returned.
 func createGameHandler(w http.ResponseWriter, r *http.Request) {
-	newGame := createScrabbleGame()
+	var j GeneralGameRequest
 
-	resp := GeneralGameRequest{
-		GameID: newGame.ID,
+	if r.Body != nil {
+		err := json.NewDecoder(r.Body).Decode(&j)
+		if err != nil && err != io.EOF {
+			writeError(w, err)
+			return
+		}
 	}
 
-	serverMu.Lock()
-	server.activeGames[newGame.ID] = newGame
-	serverMu.Unlock()
+	resp, err := createGame(j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
 
 	gameData, err := json.Marshal(resp)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, internalError(err))
 		return
 	}
 
@@ -94,28 +160,21 @@ func createGameHandler(w http.ResponseWriter, r *http.Request) {
 // also creates a player and returns their ID to the client.
 func joinGameHandler(w http.ResponseWriter, r *http.Request) {
 	var j GeneralGameRequest
-	var g *ScrabbleGame
 
 	// Decode Game ID
 	err := json.NewDecoder(r.Body).Decode(&j)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
-	}
-
-	// Retrieve the game that matches ID requested
-	g, err = getGame(j.GameID, w)
-	if err != nil {
+	} else if j.PlayerName == nil {
+		writeError(w, errPlayerNameRequired)
 		return
 	}
 
-	g.Lock()
-	defer g.Unlock()
-
 	// Set field in response so player knows their ID
-	playerID, err := g.addPlayer(*j.PlayerName)
+	playerID, err := joinGame(j.GameID, *j.PlayerName, j.Team)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
@@ -124,7 +183,7 @@ func joinGameHandler(w http.ResponseWriter, r *http.Request) {
 	// Create response containing game ID and new player ID
 	resp, err := json.Marshal(j)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, internalError(err))
 		return
 	}
 
@@ -133,32 +192,22 @@ func joinGameHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write(resp)
 }
 
-// startGameHandler is a handler that will start a game upon request, marking it
-// as active and no longer joinable by other players. It also kicks off the
-// goroutine for the specified game.
+// startGameHandler is a handler that will start a game upon request from the
+// host, marking it as active and no longer joinable by other players. It also
+// kicks off the goroutine for the specified game.
 func startGameHandler(w http.ResponseWriter, r *http.Request) {
 	var j GeneralGameRequest
-	var g *ScrabbleGame
 
 	// Decode Game ID
 	err := json.NewDecoder(r.Body).Decode(&j)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
-	// Retrieve game instance
-	g, err = getGame(j.GameID, w)
-	if err != nil {
-		return
-	}
-
-	// Start game
-	g.Lock()
-	defer g.Unlock()
-	err = g.start()
+	err = hostStartGame(j.GameID, j.PlayerID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -167,7 +216,9 @@ func startGameHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // gameStateHandler handles requests for the game's current state. It will
-// respond using the GameStateResponse struct.
+// respond using the GameStateResponse struct, or with the state drawn as text
+// if the format query parameter is text. Text is colored with ANSI escape
+// codes if the color query parameter is true.
 func gameStateHandler(w http.ResponseWriter, r *http.Request) {
 	var j GeneralGameRequest
 
@@ -175,15 +226,122 @@ func gameStateHandler(w http.ResponseWriter, r *http.Request) {
 	// which tiles to send for the player's current state.
 	err := json.NewDecoder(r.Body).Decode(&j)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
+		return
+	} else if j.PlayerID == nil {
+		writeError(w, errPlayerIDRequired)
 		return
 	}
 
-	// Send request to game controller
-	gameRequestHelper(GamePlayRequest{
+	request := GamePlayRequest{
 		GameID:   j.GameID,
 		PlayerID: *j.PlayerID,
-	}, w)
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		// Send request to game controller
+		gameRequestHelper(request, w)
+		return
+	case "text":
+	default:
+		writeError(w, newError(CodeBadRequest, "Format must be json or text"))
+		return
+	}
+
+	color, err := queryBool(r.URL.Query(), "color")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	state, err := requestGame(request)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(state.text(color)))
+}
+
+// unseenTilesHandler handles requests for the tiles the player has not yet
+// seen on the board or in their own rack. It will respond using the
+// UnseenTilesResponse struct.
+func unseenTilesHandler(w http.ResponseWriter, r *http.Request) {
+	var j GeneralGameRequest
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return
+	} else if j.PlayerID == nil {
+		writeError(w, errPlayerIDRequired)
+		return
+	}
+
+	g, err := getGame(j.GameID, w)
+	if err != nil {
+		return
+	}
+
+	// Rules are fixed once the game is created
+	g.Lock()
+	rules := g.Rules
+	g.Unlock()
+
+	if rules.DisableTileTracking {
+		writeError(w, newError(CodeForbidden, "Tile tracking is disabled for this game"))
+		return
+	}
+
+	state, err := g.request(GamePlayRequest{
+		GameID:   j.GameID,
+		PlayerID: *j.PlayerID,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	unseen, err := unseenTiles(state)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	resp, err := json.Marshal(unseen)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// inboxHandler handles requests for the games in which any of the specified
+// players is up next. It will respond with a list of InboxEntry.
+func inboxHandler(w http.ResponseWriter, r *http.Request) {
+	var j InboxRequest
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := json.Marshal(inbox(j.PlayerIDs))
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
 }
 
 // gamePlayHandler handles requests from players to play a word. It will respond
@@ -193,32 +351,28 @@ func gamePlayHandler(w http.ResponseWriter, r *http.Request) {
 
 	err := json.NewDecoder(r.Body).Decode(&j)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
+	j.Play = true
 	gameRequestHelper(j, w)
 }
 
 // gameRequestHelper relays play and state requests to the game, since they are
 // the exact same flow
 func gameRequestHelper(j GamePlayRequest, w http.ResponseWriter) {
-	// Get game to send message to
-	g, err := getGame(j.GameID, w)
-	if err != nil {
-		return
-	}
-
 	// Send state or play request and wait for response
-	state, err := g.request(j)
+	state, err := requestGame(j)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
+		return
 	}
 
 	// Return GameStateResponse as json
 	resp, err := json.Marshal(state)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, internalError(err))
 		return
 	}
 
@@ -227,16 +381,92 @@ func gameRequestHelper(j GamePlayRequest, w http.ResponseWriter) {
 	w.Write(resp)
 }
 
-// getGame is a concurrency-safe function that retrieves the requested game
-// instance from the list of active games on the server
+// getGame retrieves the requested game, responding with an error if there is
+// no such game
 func getGame(gameID uuid.UUID, w http.ResponseWriter) (*ScrabbleGame, error) {
-	var g *ScrabbleGame
-	var ok bool
+	g, err := findGame(gameID)
+	if err != nil {
+		writeError(w, err)
+	}
+	return g, err
+}
+
+// findGame is a concurrency-safe function that retrieves the requested game
+// instance from the list of active games on the server
+func findGame(gameID uuid.UUID) (*ScrabbleGame, error) {
 	serverMu.Lock()
 	defer serverMu.Unlock()
-	if g, ok = server.activeGames[gameID]; !ok {
-		http.Error(w, "No existing game with that ID", http.StatusBadRequest)
-		return nil, errors.New("Game does not exist")
+	if g, ok := server.activeGames[gameID]; ok {
+		return g, nil
+	}
+	return nil, errGameNotFound
+}
+
+// createGame creates a game with the rules requested and makes it available
+// to join. If the request has a player name, the creator joins the game as its
+// host. It returns the game's ID and rules, and the host's ID if they joined.
+func createGame(j GeneralGameRequest) (GeneralGameRequest, error) {
+	newGame := createScrabbleGame()
+	if j.Rules != nil {
+		newGame.Rules = *j.Rules
+	}
+
+	resp := GeneralGameRequest{
+		GameID: newGame.ID,
+		Rules:  &newGame.Rules,
+	}
+
+	if j.PlayerName != nil {
+		playerID, err := newGame.addTeamPlayer(*j.PlayerName, j.Team)
+		if err != nil {
+			return resp, err
+		}
+		resp.PlayerID = &playerID
+		resp.PlayerName = j.PlayerName
+	}
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	return resp, nil
+}
+
+// joinGame adds a player to a game, on the team they chose if any, and
+// returns their ID
+func joinGame(gameID uuid.UUID, name string, team *int) (uuid.UUID, error) {
+	g, err := findGame(gameID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	g.Lock()
+	defer g.Unlock()
+	return g.addTeamPlayer(name, team)
+}
+
+// hostStartGame starts a game at the request of its host
+func hostStartGame(gameID uuid.UUID, playerID *uuid.UUID) error {
+	g, err := findGame(gameID)
+	if err != nil {
+		return err
+	}
+
+	g.Lock()
+	defer g.Unlock()
+	if !g.isHost(playerID) {
+		return errNotHost
+	}
+	return g.start()
+}
+
+// requestGame sends a play or state request to the game's state controller
+// and waits for the player's state in response. Games that have not started
+// have no state controller, so requests to them fail rather than wait.
+func requestGame(j GamePlayRequest) (GameStateResponse, error) {
+	g, err := findGame(j.GameID)
+	if err != nil {
+		return GameStateResponse{}, err
 	}
-	return g, nil
+	return g.request(j)
 }
@@ -113,7 +113,7 @@ failJoinLoop:
 			t.Fatal(err)
 		case rr := <-joinCh:
 			rrCount++
-			if rr.Code != http.StatusBadRequest {
+			if rr.Code != http.StatusConflict || responseError(t, rr).Code != CodeGameFull {
 				t.Error("Should have failed to add player")
 			}
 			if rrCount == 2 {
@@ -164,10 +164,11 @@ func TestStartGameHandler(t *testing.T) {
 		"ashley4",
 	}
 
-	newGame.addPlayer(playerNames[0])
+	hostID, _ := newGame.addPlayer(playerNames[0])
 
 	j := GeneralGameRequest{
-		GameID: newGame.ID,
+		GameID:   newGame.ID,
+		PlayerID: &hostID,
 	}
 
 	payload, err := json.Marshal(j)
@@ -181,13 +182,30 @@ func TestStartGameHandler(t *testing.T) {
 	}
 
 	// Should fail with only one player
-	if c := rr.Code; c == http.StatusOK {
-		t.Fatal("Game should not start with one player")
+	if c := rr.Code; c != http.StatusConflict || responseError(t, rr).Code != CodeNotEnoughPlayers {
+		t.Fatalf("Game should not start with one player. Status %v, body %v", c, rr.Body)
 	}
 
 	// Add remaining players so game should start
+	var playerID uuid.UUID
 	for i := 1; i < len(playerNames); i++ {
-		newGame.addPlayer(playerNames[i])
+		playerID, _ = newGame.addPlayer(playerNames[i])
+	}
+
+	// Only the host can start the game
+	j.PlayerID = &playerID
+	nonHostPayload, err := json.Marshal(j)
+	if err != nil {
+		t.Fatal("Failed to marshal JSON request object")
+	}
+
+	rr, err = startGame(nonHostPayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c := rr.Code; c != http.StatusForbidden {
+		t.Fatalf("Returned status code %v, expected %v", c, http.StatusForbidden)
 	}
 
 	rr, err = startGame(payload)
@@ -207,8 +225,8 @@ func TestStartGameHandler(t *testing.T) {
 	}
 
 	// Make sure it is unsuccessful if already started
-	if c := rr.Code; c == http.StatusOK {
-		t.Fatal("Second start attempt should have failed")
+	if c := rr.Code; c != http.StatusConflict || responseError(t, rr).Code != CodeGameStarted {
+		t.Fatalf("Second start attempt should have failed. Status %v, body %v", c, rr.Body)
 	}
 }
 
@@ -291,3 +309,73 @@ func TestGameStateHandler(t *testing.T) {
 		t.Fatal("Incorrect number of tiles for player")
 	}
 }
+
+func TestGamePlayHandler(t *testing.T) {
+	newGame := createScrabbleGame()
+	playerIDs := make([]uuid.UUID, 2)
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	newGame.start()
+
+	// Look up the first player's tiles so they can swap one
+	s, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := playRequest(t, GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: playerIDs[0],
+		Tiles:    s.PlayerTiles[:1],
+		Swap:     true,
+	})
+
+	if c := rr.Code; c != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v. Error: %v", c, http.StatusOK, rr.Body)
+	}
+
+	err = json.NewDecoder(rr.Body).Decode(&s)
+	if err != nil {
+		t.Fatal("Response was not in correct format")
+	} else if s.PlayerTurn != 1 {
+		t.Fatalf("Turn did not pass to second player")
+	}
+
+	// First player can no longer play
+	rr = playRequest(t, GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: playerIDs[0],
+		Tiles:    s.PlayerTiles[:1],
+		Swap:     true,
+	})
+
+	if rr.Code == http.StatusOK {
+		t.Fatal("Player should not be able to play out of turn")
+	}
+}
+
+func playRequest(t *testing.T, j GamePlayRequest) *httptest.ResponseRecorder {
+	payload, err := json.Marshal(j)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("POST", "/game/play", bytes.NewBuffer(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	h := http.HandlerFunc(gamePlayHandler)
+
+	h.ServeHTTP(rr, req)
+
+	return rr
+}
@@ -0,0 +1,296 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// skillRange is the largest difference in rating between players matched
+// into the same game
+const skillRange = 200
+
+// LobbyGame is an open game listed in the lobby
+type LobbyGame struct {
+	GeneralGameRequest
+	Players []string `json:"players"` // names of the players who have joined
+	Seats   int      `json:"seats"`   // seats still open
+}
+
+// MatchRequest is the format of the request a client sends to be matched with
+// other players waiting for the same kind of game
+type MatchRequest struct {
+	PlayerName string    `json:"player_name"`
+	Rules      GameRules `json:"rules"`
+	Skill      int       `json:"skill"`           // player's rating, matched with players rated within skillRange of each other
+	Seats      int       `json:"seats,omitempty"` // players per game, two if not set
+}
+
+// matchTicket is a player waiting in the matchmaking queue
+type matchTicket struct {
+	name   string
+	skill  int
+	result chan matchResult // receives the outcome once the group is full
+}
+
+// matchResult is the game and player ID assigned to a matched player, or the
+// reason their game could not be started
+type matchResult struct {
+	GeneralGameRequest
+	err error
+}
+
+// matchmaker queues waiting players by ruleset and number of seats, and starts
+// a game once enough players in a queue are rated close enough to each other
+type matchmaker struct {
+	sync.Mutex
+	queues map[string][]*matchTicket
+}
+
+var matchmaking = matchmaker{
+	queues: make(map[string][]*matchTicket),
+}
+
+// openGames lists the games that have not started yet and are not locked
+func openGames() []LobbyGame {
+	games := make([]LobbyGame, 0)
+
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
+	for _, g := range server.activeGames {
+		g.Lock()
+		if !g.Active && !g.Locked {
+			rules := g.Rules
+			lg := LobbyGame{
+				GeneralGameRequest: GeneralGameRequest{
+					GameID: g.ID,
+					Rules:  &rules,
+				},
+				Players: make([]string, len(g.Players)),
+				Seats:   maxPlayers - len(g.Players),
+			}
+			for _, p := range g.Players {
+				lg.Players[p.Number] = p.Name
+			}
+			games = append(games, lg)
+		}
+		g.Unlock()
+	}
+
+	return games
+}
+
+// queueKey identifies the queue of players who want the same kind of game
+func queueKey(j MatchRequest) (string, error) {
+	rules, err := json.Marshal(j.Rules)
+	if err != nil {
+		return "", err
+	}
+	return string(rules) + "/" + strconv.Itoa(j.Seats), nil
+}
+
+// matchGroup picks the longest waiting players from the queue who are all
+// rated within skillRange of each other and of the newest player, which is
+// last in the queue. The group is in the order its players joined the queue,
+// or nil if there are not enough of them to fill the seats.
+func matchGroup(queue []*matchTicket, seats int) []*matchTicket {
+	newest := queue[len(queue)-1]
+	group := []*matchTicket{newest}
+
+	for _, t := range queue[:len(queue)-1] {
+		fits := true
+		for _, member := range group {
+			if gap := t.skill - member.skill; gap > skillRange || gap < -skillRange {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			group = append(group, t)
+		}
+		if len(group) == seats {
+			return append(group[1:], newest)
+		}
+	}
+
+	return nil
+}
+
+// join adds a player to the matchmaking queue. If their group is now full, a
+// game is created and started for the group.
+func (m *matchmaker) join(j MatchRequest) (string, *matchTicket, error) {
+	if j.PlayerName == "" {
+		return "", nil, errors.New("Player name is required")
+	}
+	if j.Seats == 0 {
+		j.Seats = 2
+	}
+	if j.Seats < 2 || j.Seats > maxPlayers {
+		return "", nil, errors.New("Games must have between two and four players")
+	}
+
+	// Rules that could never start a game would leave the matched players
+	// waiting for nothing, so they are turned away before queueing
+	if err := j.Rules.validate(); err != nil {
+		return "", nil, err
+	} else if j.Rules.Teams && j.Seats != teamCount*2 {
+		return "", nil, newError(CodeInvalidRules, "Team games need exactly four players")
+	}
+
+	key, err := queueKey(j)
+	if err != nil {
+		return "", nil, err
+	}
+
+	t := &matchTicket{
+		name:   j.PlayerName,
+		skill:  j.Skill,
+		result: make(chan matchResult, 1),
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.queues[key] = append(m.queues[key], t)
+	group := matchGroup(m.queues[key], j.Seats)
+	if group == nil {
+		return key, t, nil
+	}
+
+	for _, matched := range group {
+		m.remove(key, matched)
+	}
+
+	startMatch(j.Rules, group)
+
+	return key, t, nil
+}
+
+// leave removes a player from the matchmaking queue. It returns false if the
+// player was already matched.
+func (m *matchmaker) leave(key string, t *matchTicket) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	return m.remove(key, t)
+}
+
+// remove takes a player out of the queue, and reports whether they were in
+// it. The caller must hold the lock.
+func (m *matchmaker) remove(key string, t *matchTicket) bool {
+	queue := m.queues[key]
+	for i := range queue {
+		if queue[i] == t {
+			m.queues[key] = append(queue[:i], queue[i+1:]...)
+			if len(m.queues[key]) == 0 {
+				delete(m.queues, key)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// startMatch creates a game for a full group, starts it, and tells each player
+// their game and player ID. The game is only registered once it has started.
+func startMatch(rules GameRules, group []*matchTicket) {
+	results := make([]matchResult, len(group))
+	defer func() {
+		for i, t := range group {
+			t.result <- results[i]
+		}
+	}()
+
+	fail := func(err error) {
+		for i := range results {
+			results[i] = matchResult{err: err}
+		}
+	}
+
+	g := createScrabbleGame()
+	g.Rules = rules
+
+	for i, t := range group {
+		playerID, err := g.addPlayer(t.name)
+		if err != nil {
+			fail(err)
+			return
+		}
+		results[i].GeneralGameRequest = GeneralGameRequest{GameID: g.ID, PlayerID: &playerID}
+	}
+
+	g.Lock()
+	err := g.start()
+	g.Unlock()
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	serverMu.Lock()
+	server.activeGames[g.ID] = g
+	serverMu.Unlock()
+}
+
+// lobbyHandler handles requests for the list of games that can be joined. It
+// will respond with a list of LobbyGame.
+func lobbyHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := json.Marshal(openGames())
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// matchHandler handles requests from players to be matched into a game. The
+// request is held open until the game starts, and the response contains the
+// game ID and the player's new ID.
+func matchHandler(w http.ResponseWriter, r *http.Request) {
+	var j MatchRequest
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	key, t, err := matchmaking.join(j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var match matchResult
+	select {
+	case match = <-t.result:
+	case <-r.Context().Done():
+		if matchmaking.leave(key, t) {
+			return
+		}
+		// Matched while giving up, so the seat is already taken
+		match = <-t.result
+	}
+
+	if match.err != nil {
+		writeError(w, internalError(match.err))
+		return
+	}
+
+	resp, err := json.Marshal(match.GeneralGameRequest)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
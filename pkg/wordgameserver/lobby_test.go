@@ -0,0 +1,217 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+func TestLobbyHandler(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.ClockMinutes = 25
+	newGame.addPlayer("ashley1")
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	req, err := http.NewRequest("GET", "/lobby/games", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(lobbyHandler).ServeHTTP(rr, req)
+
+	var games []LobbyGame
+	err = json.NewDecoder(rr.Body).Decode(&games)
+	if err != nil {
+		t.Fatal("Response was not in correct format")
+	}
+
+	for _, g := range games {
+		if g.GameID != newGame.ID {
+			continue
+		}
+		if g.Seats != maxPlayers-1 || len(g.Players) != 1 {
+			t.Errorf("Expected %v open seats and 1 player, got %v and %v", maxPlayers-1, g.Seats, g.Players)
+		} else if g.Rules == nil || g.Rules.ClockMinutes != 25 {
+			t.Errorf("Lobby did not include game's rules")
+		}
+		return
+	}
+
+	t.Fatal("Open game not listed in lobby")
+}
+
+func TestConcurrentMatchmaking(t *testing.T) {
+	const playerCount = 12
+
+	rules := GameRules{ClockMinutes: 17}
+	results := make(chan GeneralGameRequest)
+	errCh := make(chan error)
+
+	for i := 0; i < playerCount; i++ {
+		go func(i int) {
+			rr := matchRequest(context.Background(), t, MatchRequest{
+				PlayerName: "ashley" + strconv.Itoa(i),
+				Rules:      rules,
+				Skill:      1500,
+				Seats:      3,
+			})
+			if rr.Code != http.StatusOK {
+				errCh <- errors.New(rr.Body.String())
+				return
+			}
+			var j GeneralGameRequest
+			if err := json.NewDecoder(rr.Body).Decode(&j); err != nil {
+				errCh <- err
+				return
+			}
+			results <- j
+		}(i)
+	}
+
+	games := make(map[uuid.UUID]int)
+	players := make(map[uuid.UUID]bool)
+
+	for i := 0; i < playerCount; i++ {
+		select {
+		case j := <-results:
+			games[j.GameID]++
+			players[*j.PlayerID] = true
+		case err := <-errCh:
+			t.Fatal(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for matches")
+		}
+	}
+
+	if len(games) != playerCount/3 {
+		t.Fatalf("Expected %v games, got %v", playerCount/3, len(games))
+	} else if len(players) != playerCount {
+		t.Fatalf("Expected %v distinct players, got %v", playerCount, len(players))
+	}
+
+	for id, count := range games {
+		g, err := getGame(id, httptest.NewRecorder())
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.Lock()
+		active, gameRules := g.Active, g.Rules
+		g.Unlock()
+		if count != 3 || !active {
+			t.Errorf("Game %v has %v matched players and active %v", id, count, active)
+		} else if gameRules.ClockMinutes != rules.ClockMinutes {
+			t.Errorf("Game %v was not created with the requested rules", id)
+		}
+	}
+}
+
+func TestMatchmakingSkillBands(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	done := make(chan *httptest.ResponseRecorder)
+	for _, skill := range []int{1000, 2000} {
+		go func(skill int) {
+			done <- matchRequest(ctx, t, MatchRequest{PlayerName: "ashley", Skill: skill})
+		}(skill)
+	}
+
+	// Neither player should be sent a game before giving up
+	for i := 0; i < 2; i++ {
+		if rr := <-done; rr.Body.Len() != 0 {
+			t.Fatalf("Players of different skill should not be matched. Returned: %v", rr.Body)
+		}
+	}
+
+	matchmaking.Lock()
+	defer matchmaking.Unlock()
+	if len(matchmaking.queues) != 0 {
+		t.Fatalf("Players who gave up were left in queue: %v", matchmaking.queues)
+	}
+}
+
+func TestMatchInvalidRules(t *testing.T) {
+	tests := []struct {
+		j    MatchRequest
+		code ErrorCode
+	}{
+		{MatchRequest{PlayerName: "ashley", Rules: GameRules{Duplicate: true, ClockMinutes: 5}}, CodeInvalidRules},
+		{MatchRequest{PlayerName: "ashley", Rules: GameRules{Dictionary: "missing"}}, CodeUnknownDictionary},
+		{MatchRequest{PlayerName: "ashley", Rules: GameRules{TurnOrder: "sideways"}}, CodeInvalidRules},
+		{MatchRequest{PlayerName: "ashley", Rules: GameRules{Teams: true}, Seats: 3}, CodeInvalidRules},
+	}
+
+	// Players are turned away at once instead of waiting for a game that
+	// could never start
+	for _, test := range tests {
+		rr := matchRequest(context.Background(), t, test.j)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%+v: status %v, expected %v", test.j.Rules, rr.Code, http.StatusBadRequest)
+		} else if e := responseError(t, rr); e.Code != test.code {
+			t.Errorf("%+v: expected %v, got %+v", test.j.Rules, test.code, e)
+		}
+	}
+
+	matchmaking.Lock()
+	defer matchmaking.Unlock()
+	if len(matchmaking.queues) != 0 {
+		t.Fatalf("Players with invalid rules were queued: %v", matchmaking.queues)
+	}
+}
+
+func TestMatchGroup(t *testing.T) {
+	tickets := func(skills ...int) []*matchTicket {
+		queue := make([]*matchTicket, len(skills))
+		for i, s := range skills {
+			queue[i] = &matchTicket{name: "ashley" + strconv.Itoa(i), skill: s}
+		}
+		return queue
+	}
+
+	// Close ratings are matched even when they straddle a multiple of the range
+	if group := matchGroup(tickets(1190, 1210), 2); len(group) != 2 {
+		t.Error("Players rated 20 apart should be matched")
+	}
+
+	if group := matchGroup(tickets(1000, 1201), 2); group != nil {
+		t.Error("Players rated more than the range apart should not be matched")
+	}
+
+	// Everyone in the group must be close to everyone else, and the group
+	// keeps the order players joined in
+	queue := tickets(1000, 1300, 1150, 1250)
+	if group := matchGroup(queue[:3], 3); group != nil {
+		t.Errorf("Players rated 300 apart were matched: %v", group)
+	} else if group := matchGroup(queue, 3); len(group) != 3 || group[0] != queue[1] || group[1] != queue[2] || group[2] != queue[3] {
+		t.Errorf("Expected the last three players to be matched, got %v", group)
+	}
+}
+
+func matchRequest(ctx context.Context, t *testing.T, j MatchRequest) *httptest.ResponseRecorder {
+	payload, err := json.Marshal(j)
+	if err != nil {
+		t.Error(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "/lobby/match", bytes.NewBuffer(payload))
+	if err != nil {
+		t.Error(err)
+	}
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(matchHandler).ServeHTTP(rr, req)
+
+	return rr
+}
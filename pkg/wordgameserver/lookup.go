@@ -0,0 +1,211 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// maxLookupBlanks is the most blanks a rack can have in an anagram lookup
+const maxLookupBlanks = 2
+
+// WordLookupRequest is the format of the request a client sends to look words
+// up in a dictionary. Blanks in racks are written as ? and patterns use ? for
+// any single letter.
+type WordLookupRequest struct {
+	Dictionary string `json:"dictionary"`
+	Word       string `json:"word,omitempty"`    // word to check or find hooks for
+	Rack       string `json:"rack,omitempty"`    // letters to anagram, with ? for a blank
+	Build      bool   `json:"build,omitempty"`   // include words using only some of the rack
+	Pattern    string `json:"pattern,omitempty"` // pattern such as ?A?E
+}
+
+// WordLookupResponse is the format of the response to a word lookup. Only the
+// fields for the kind of lookup are set.
+type WordLookupResponse struct {
+	Dictionary string   `json:"dictionary"`
+	Word       string   `json:"word,omitempty"`
+	Valid      *bool    `json:"valid,omitempty"`
+	Words      []string `json:"words,omitempty"`
+	FrontHooks string   `json:"front_hooks,omitempty"` // letters that can go before the word
+	BackHooks  string   `json:"back_hooks,omitempty"`  // letters that can go after the word
+}
+
+// AnagramsWithBlanks returns the words that use every letter of the rack,
+// with each ? standing for any letter. Any other characters are ignored. If
+// build is true, words using only some of the rack are included too. Words are
+// sorted by length, longest first, then alphabetically.
+func (d *Dictionary) AnagramsWithBlanks(rack string, build bool) []string {
+	var counts [26]int
+	blanks, size := 0, 0
+	rack = strings.ToUpper(rack)
+	for i := 0; i < len(rack); i++ {
+		switch l := rack[i]; {
+		case l == '?':
+			blanks++
+			size++
+		case l >= 'A' && l <= 'Z':
+			counts[l-'A']++
+			size++
+		}
+	}
+
+	var words []string
+	for _, w := range d.list {
+		if len(w) > size || (!build && len(w) != size) {
+			continue
+		}
+		if canSpell(w, counts, [26]int{}, blanks) {
+			words = append(words, w)
+		}
+	}
+
+	sort.SliceStable(words, func(i, j int) bool {
+		return len(words[i]) > len(words[j])
+	})
+
+	return words
+}
+
+// Match returns the words that fit the pattern, where ? matches any letter
+func (d *Dictionary) Match(pattern string) []string {
+	pattern = strings.ToUpper(pattern)
+
+	var words []string
+	for _, w := range d.list {
+		if len(w) != len(pattern) {
+			continue
+		}
+		matched := true
+		for i := 0; i < len(w); i++ {
+			if pattern[i] != '?' && pattern[i] != w[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			words = append(words, w)
+		}
+	}
+
+	return words
+}
+
+// Hooks returns the letters that can be added before and after the word to
+// form another word
+func (d *Dictionary) Hooks(word string) (front, back string) {
+	word = strings.ToUpper(word)
+	for l := byte('A'); l <= 'Z'; l++ {
+		if d.words[string(l)+word] {
+			front += string(l)
+		}
+		if d.words[word+string(l)] {
+			back += string(l)
+		}
+	}
+	return front, back
+}
+
+// lookupRequestHelper decodes a word lookup request and finds the dictionary
+// it asks for
+func lookupRequestHelper(j *WordLookupRequest, w http.ResponseWriter, r *http.Request) (*Dictionary, bool) {
+	err := json.NewDecoder(r.Body).Decode(j)
+	if err != nil {
+		writeError(w, err)
+		return nil, false
+	}
+
+	d, ok := getDictionary(j.Dictionary)
+	if !ok {
+		writeError(w, unknownDictionary(j.Dictionary))
+		return nil, false
+	}
+
+	return d, true
+}
+
+// writeLookupResponse sends the response to a word lookup
+func writeLookupResponse(w http.ResponseWriter, l WordLookupResponse) {
+	if l.Words == nil {
+		l.Words = []string{}
+	}
+
+	resp, err := json.Marshal(l)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// checkWordHandler handles requests to check whether a word is valid
+func checkWordHandler(w http.ResponseWriter, r *http.Request) {
+	var j WordLookupRequest
+
+	d, ok := lookupRequestHelper(&j, w, r)
+	if !ok {
+		return
+	}
+
+	valid := d.Contains(j.Word)
+	writeLookupResponse(w, WordLookupResponse{
+		Dictionary: d.Name,
+		Word:       strings.ToUpper(j.Word),
+		Valid:      &valid,
+	})
+}
+
+// anagramHandler handles requests for the anagrams of a rack
+func anagramHandler(w http.ResponseWriter, r *http.Request) {
+	var j WordLookupRequest
+
+	d, ok := lookupRequestHelper(&j, w, r)
+	if !ok {
+		return
+	} else if strings.Count(j.Rack, "?") > maxLookupBlanks {
+		writeError(w, newError(CodeBadRequest, "Racks can have at most two blanks"))
+		return
+	}
+
+	writeLookupResponse(w, WordLookupResponse{
+		Dictionary: d.Name,
+		Words:      d.AnagramsWithBlanks(j.Rack, j.Build),
+	})
+}
+
+// patternHandler handles requests for the words matching a pattern
+func patternHandler(w http.ResponseWriter, r *http.Request) {
+	var j WordLookupRequest
+
+	d, ok := lookupRequestHelper(&j, w, r)
+	if !ok {
+		return
+	}
+
+	writeLookupResponse(w, WordLookupResponse{
+		Dictionary: d.Name,
+		Words:      d.Match(j.Pattern),
+	})
+}
+
+// hooksHandler handles requests for the letters that hook onto a word
+func hooksHandler(w http.ResponseWriter, r *http.Request) {
+	var j WordLookupRequest
+
+	d, ok := lookupRequestHelper(&j, w, r)
+	if !ok {
+		return
+	}
+
+	front, back := d.Hooks(j.Word)
+	writeLookupResponse(w, WordLookupResponse{
+		Dictionary: d.Name,
+		Word:       strings.ToUpper(j.Word),
+		FrontHooks: front,
+		BackHooks:  back,
+	})
+}
@@ -0,0 +1,96 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAnagramsWithBlanks(t *testing.T) {
+	d := testDictionary(t)
+
+	if words := d.AnagramsWithBlanks("tca", false); !reflect.DeepEqual(words, []string{"ACT", "CAT", "TAC"}) {
+		t.Errorf("Anagrams of TCA are %v", words)
+	}
+	if words := d.AnagramsWithBlanks("t?", false); !reflect.DeepEqual(words, []string{"AT", "TA"}) {
+		t.Errorf("Anagrams of T? are %v", words)
+	}
+	if words := d.AnagramsWithBlanks("ACT", true); !reflect.DeepEqual(words, []string{"ACT", "CAT", "TAC", "AT", "TA"}) {
+		t.Errorf("Words built from ACT are %v", words)
+	}
+
+	// Only letters and blanks count towards the length of the rack
+	if words := d.AnagramsWithBlanks("t-a c", false); !reflect.DeepEqual(words, []string{"ACT", "CAT", "TAC"}) {
+		t.Errorf("Anagrams of T-A C are %v", words)
+	}
+}
+
+func TestMatchAndHooks(t *testing.T) {
+	d := testDictionary(t)
+
+	if words := d.Match("?a?"); !reflect.DeepEqual(words, []string{"CAT", "TAC"}) {
+		t.Errorf("Words matching ?A? are %v", words)
+	}
+
+	front, back := d.Hooks("at")
+	if front != "C" || back != "" {
+		t.Errorf("Hooks of AT are %q and %q, expected C and none", front, back)
+	}
+}
+
+func TestWordLookupHandlers(t *testing.T) {
+	testDictionary(t)
+
+	lookup := func(handler http.HandlerFunc, j WordLookupRequest) (int, WordLookupResponse) {
+		body, _ := json.Marshal(j)
+		rr := httptest.NewRecorder()
+		handler(rr, httptest.NewRequest("GET", "/words", bytes.NewReader(body)))
+
+		var resp WordLookupResponse
+		if rr.Code == http.StatusOK {
+			if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return rr.Code, resp
+	}
+
+	code, resp := lookup(checkWordHandler, WordLookupRequest{Dictionary: "test", Word: "zoa"})
+	if code != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", code, http.StatusOK)
+	} else if resp.Valid == nil || !*resp.Valid || resp.Word != "ZOA" {
+		t.Errorf("ZOA was not reported valid: %+v", resp)
+	}
+
+	code, resp = lookup(anagramHandler, WordLookupRequest{Dictionary: "test", Rack: "??"})
+	if code != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", code, http.StatusOK)
+	} else if len(resp.Words) != 2 {
+		t.Errorf("Expected 2 two-letter words, got %v", resp.Words)
+	}
+
+	// Spaces are not blanks
+	code, resp = lookup(anagramHandler, WordLookupRequest{Dictionary: "test", Rack: "? ? t"})
+	if code != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", code, http.StatusOK)
+	} else if len(resp.Words) != 3 {
+		t.Errorf("Expected 3 three-letter words, got %v", resp.Words)
+	}
+
+	code, resp = lookup(hooksHandler, WordLookupRequest{Dictionary: "test", Word: "at"})
+	if code != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", code, http.StatusOK)
+	} else if resp.FrontHooks != "C" {
+		t.Errorf("Front hooks of AT are %q, expected C", resp.FrontHooks)
+	}
+
+	if code, _ = lookup(anagramHandler, WordLookupRequest{Dictionary: "test", Rack: "???"}); code != http.StatusBadRequest {
+		t.Errorf("Returned status code %v, expected %v", code, http.StatusBadRequest)
+	}
+	if code, _ = lookup(patternHandler, WordLookupRequest{Dictionary: "missing", Pattern: "?"}); code != http.StatusBadRequest {
+		t.Errorf("Returned status code %v, expected %v", code, http.StatusBadRequest)
+	}
+}
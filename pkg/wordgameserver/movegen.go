@@ -0,0 +1,175 @@
+package wordgameserver
+
+import (
+	"sort"
+	"strings"
+)
+
+// Move is a play found by the move generator
+type Move struct {
+	StartPos SquareCoordinate `json:"start_pos"`
+	EndPos   SquareCoordinate `json:"end_pos"`
+	Tiles    []byte           `json:"tiles"`
+	Blanks   []byte           `json:"blanks,omitempty"`
+	Words    []string         `json:"words"` // words formed, main word first, blanks lowercase
+	Score    int              `json:"score"`
+}
+
+// request converts the move into the request a player would send to play it
+func (m Move) request() GamePlayRequest {
+	return GamePlayRequest{
+		StartPos: m.StartPos,
+		EndPos:   m.EndPos,
+		Tiles:    m.Tiles,
+		Blanks:   m.Blanks,
+	}
+}
+
+// generateMoves finds every play of the rack on the board that forms only
+// dictionary words, and returns them best first. Each dictionary word is
+// tried at every position along every row and column it could fit. Blanks
+// stand in for letters missing from the rack.
+func (sb *ScrabbleBoard) generateMoves(rack []byte, d *Dictionary) []Move {
+	var rackCounts [26]int
+	blanks := 0
+	for _, t := range rack {
+		if t == ' ' {
+			blanks++
+		} else if t >= 'A' && t <= 'Z' {
+			rackCounts[t-'A']++
+		}
+	}
+
+	moves := make([]Move, 0)
+	seen := make(map[string]bool)
+
+	for _, down := range []bool{false, true} {
+		for line := 0; line < rowCount; line++ {
+			square := func(i int) SquareCoordinate {
+				if down {
+					return SquareCoordinate{Row: i, Col: line}
+				}
+				return SquareCoordinate{Row: line, Col: i}
+			}
+
+			// Count the letters already on the line
+			var lineCounts [26]int
+			for i := 0; i < columnCount; i++ {
+				if sc := square(i); sb.occupied(sc) {
+					lineCounts[sb[sc.Row][sc.Col].Letter-'A']++
+				}
+			}
+
+			for _, word := range d.list {
+				if len(word) < 2 || len(word) > columnCount || !canSpell(word, rackCounts, lineCounts, blanks) {
+					continue
+				}
+
+				for start := 0; start+len(word) <= columnCount; start++ {
+					end := start + len(word) - 1
+					if sb.occupied(square(start-1)) || sb.occupied(square(end+1)) {
+						continue
+					}
+
+					m, ok := sb.fitWord(word, square, start, rackCounts, blanks)
+					if !ok {
+						continue
+					}
+
+					p, err := sb.evaluatePlay(m.request())
+					if err != nil || !d.containsAll(p.words) {
+						continue
+					}
+
+					key := placementKey(p)
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+
+					m.Words, m.Score = p.words, p.score
+					moves = append(moves, m)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(moves, func(i, j int) bool {
+		return moves[i].Score > moves[j].Score
+	})
+
+	return moves
+}
+
+// canSpell reports whether the word's letters are available from the rack and
+// the letters on the line, with blanks making up any shortfall
+func canSpell(word string, rackCounts, lineCounts [26]int, blanks int) bool {
+	var need [26]int
+	for i := 0; i < len(word); i++ {
+		need[word[i]-'A']++
+	}
+	short := 0
+	for l, n := range need {
+		if n > rackCounts[l]+lineCounts[l] {
+			short += n - rackCounts[l] - lineCounts[l]
+		}
+	}
+	return short <= blanks
+}
+
+// fitWord lays a word along a line from the start position, using the tiles on
+// the board and filling the empty squares from the rack. It fails if a board
+// tile does not match or the rack runs out, or if no tile would be placed.
+func (sb *ScrabbleBoard) fitWord(word string, square func(int) SquareCoordinate, start int, rackCounts [26]int, blanks int) (Move, bool) {
+	m := Move{
+		StartPos: square(start),
+		EndPos:   square(start + len(word) - 1),
+	}
+
+	for i := 0; i < len(word); i++ {
+		sc := square(start + i)
+		l := word[i]
+		if sb.occupied(sc) {
+			if sb[sc.Row][sc.Col].Letter != l {
+				return m, false
+			}
+			continue
+		}
+		switch {
+		case rackCounts[l-'A'] > 0:
+			rackCounts[l-'A']--
+			m.Tiles = append(m.Tiles, l)
+		case blanks > 0:
+			blanks--
+			m.Tiles = append(m.Tiles, ' ')
+			m.Blanks = append(m.Blanks, l)
+		default:
+			return m, false
+		}
+	}
+
+	return m, len(m.Tiles) > 0
+}
+
+// containsAll reports whether every word is in the dictionary
+func (d *Dictionary) containsAll(words []string) bool {
+	for _, w := range words {
+		if !d.Contains(w) {
+			return false
+		}
+	}
+	return true
+}
+
+// placementKey identifies the tiles a play puts on the board, so the same play
+// found along a row and a column is only listed once
+func placementKey(p placement) string {
+	var key strings.Builder
+	for i, sc := range p.squares {
+		key.WriteByte(byte(sc.Row))
+		key.WriteByte(byte(sc.Col))
+		key.WriteByte(p.tiles[i].Letter)
+		key.WriteByte(byte(p.tiles[i].Value))
+	}
+	return key.String()
+}
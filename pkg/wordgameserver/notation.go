@@ -0,0 +1,244 @@
+package wordgameserver
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Moves can be written in standard notation: the square the main word starts
+// on followed by the word, such as 8H QUIXOTE. Row first plays across and
+// column first, such as H8, plays down. Letters already on the board are in
+// parentheses and blanks are lowercase. A dash on its own is a pass, and a
+// dash followed by tiles, with ? for a blank, is a swap.
+
+// coordinate writes a square in standard notation: row first for plays
+// across, such as 8H, and column first for plays down, such as H8
+func coordinate(sc SquareCoordinate, across bool) string {
+	row, col := strconv.Itoa(sc.Row+1), string(rune('A'+sc.Col))
+	if across {
+		return row + col
+	}
+	return col + row
+}
+
+// parseCoordinate reads a square in standard notation and whether the play
+// runs across
+func parseCoordinate(s string) (SquareCoordinate, bool, error) {
+	s = strings.ToUpper(s)
+	if len(s) < 2 {
+		return SquareCoordinate{}, false, errors.New("Invalid coordinate '" + s + "'")
+	}
+
+	across := s[0] >= '0' && s[0] <= '9'
+	rowPart, col := s[1:], s[0]
+	if across {
+		rowPart, col = s[:len(s)-1], s[len(s)-1]
+	}
+
+	row, err := strconv.Atoi(rowPart)
+	sc := SquareCoordinate{Row: row - 1, Col: int(col) - 'A'}
+	if err != nil || !sc.onBoard() {
+		return SquareCoordinate{}, false, errors.New("Invalid coordinate '" + s + "'")
+	}
+	return sc, across, nil
+}
+
+// mainWord finds the main word of a play whose tiles have been placed on the
+// squares given. It returns the first square of the word, whether it runs
+// across, its letters with blanks lowercase, and which of the letters were
+// already on the board. A single tile is read across only if it forms a word
+// that way.
+func (sb *ScrabbleBoard) mainWord(squares []SquareCoordinate) (SquareCoordinate, bool, []byte, []bool) {
+	placed := make(map[SquareCoordinate]bool, len(squares))
+	for _, sc := range squares {
+		placed[sc] = true
+	}
+
+	dr, dc := 0, 1
+	switch first := squares[0]; {
+	case len(squares) > 1 && squares[1].Col == first.Col:
+		dr, dc = 1, 0
+	case len(squares) == 1 &&
+		!sb.occupied(SquareCoordinate{Row: first.Row, Col: first.Col - 1}) &&
+		!sb.occupied(SquareCoordinate{Row: first.Row, Col: first.Col + 1}):
+		dr, dc = 1, 0
+	}
+
+	start := squares[0]
+	for prev := (SquareCoordinate{Row: start.Row - dr, Col: start.Col - dc}); sb.occupied(prev); prev = (SquareCoordinate{Row: prev.Row - dr, Col: prev.Col - dc}) {
+		start = prev
+	}
+
+	var letters []byte
+	var through []bool
+	for sc := start; sb.occupied(sc); sc = (SquareCoordinate{Row: sc.Row + dr, Col: sc.Col + dc}) {
+		square := sb[sc.Row][sc.Col]
+		letter := square.Letter
+		if square.Value == 0 {
+			letter += 'a' - 'A'
+		}
+		letters = append(letters, letter)
+		through = append(through, !placed[sc])
+	}
+
+	return start, dc == 1, letters, through
+}
+
+// moveNotation writes a play whose tiles have been placed on the squares
+// given in standard notation
+func (sb *ScrabbleBoard) moveNotation(squares []SquareCoordinate) string {
+	start, across, letters, through := sb.mainWord(squares)
+
+	var b strings.Builder
+	b.WriteString(coordinate(start, across) + " ")
+	for i, l := range letters {
+		if through[i] && (i == 0 || !through[i-1]) {
+			b.WriteByte('(')
+		}
+		b.WriteByte(l)
+		if through[i] && (i == len(letters)-1 || !through[i+1]) {
+			b.WriteByte(')')
+		}
+	}
+	return b.String()
+}
+
+// moveRequest turns a coordinate and word into a play request on the board.
+// Tiles already on the board may be written in parentheses, as dots or as
+// their letters, and blanks are lowercase.
+func (sb *ScrabbleBoard) moveRequest(pos, word string) (GamePlayRequest, error) {
+	var j GamePlayRequest
+
+	sc, across, err := parseCoordinate(pos)
+	if err != nil {
+		return j, err
+	}
+	dr, dc := 1, 0
+	if across {
+		dr, dc = 0, 1
+	}
+
+	through := false
+	first := true
+	for i := 0; i < len(word); i++ {
+		l := word[i]
+		switch {
+		case l == '(' && !through:
+			through = true
+			continue
+		case l == ')' && through:
+			through = false
+			continue
+		case !sc.onBoard():
+			return j, newError(CodeInvalidPlacement, "Play '"+word+"' runs off the board")
+		}
+
+		square := sb[sc.Row][sc.Col]
+		switch {
+		case l == '.' || through:
+			if !sb.occupied(sc) || (l != '.' && square.Letter != l && square.Letter != l-'a'+'A') {
+				return j, newError(CodeInvalidPlacement, "Play '"+word+"' does not match the tiles on the board")
+			}
+		case sb.occupied(sc):
+			if square.Letter != l && square.Letter != l-'a'+'A' {
+				return j, newError(CodeInvalidPlacement, "Play '"+word+"' does not match the tiles on the board")
+			}
+		case l >= 'A' && l <= 'Z':
+			j.Tiles = append(j.Tiles, l)
+		case l >= 'a' && l <= 'z':
+			j.Tiles = append(j.Tiles, ' ')
+			j.Blanks = append(j.Blanks, l-'a'+'A')
+		default:
+			return j, errors.New("Play '" + word + "' contains letters other than A to Z")
+		}
+
+		if !sb.occupied(sc) {
+			if first {
+				j.StartPos, first = sc, false
+			}
+			j.EndPos = sc
+		}
+		sc = SquareCoordinate{Row: sc.Row + dr, Col: sc.Col + dc}
+	}
+
+	if first {
+		return j, errors.New("Play '" + word + "' does not place any tiles")
+	}
+	return j, nil
+}
+
+// resolveMove fills in a play request's positions and tiles from its move in
+// standard notation, if it has one
+func (sb *ScrabbleBoard) resolveMove(j GamePlayRequest) (GamePlayRequest, error) {
+	move := strings.TrimSpace(j.Move)
+	if move == "" {
+		return j, nil
+	}
+
+	resolved := GamePlayRequest{
+		GameID:   j.GameID,
+		PlayerID: j.PlayerID,
+		Play:     j.Play,
+	}
+
+	if strings.HasPrefix(move, "-") {
+		// Pass, or swap the tiles listed
+		if tiles := strings.TrimSpace(move[1:]); tiles != "" {
+			resolved.Tiles = []byte(strings.Replace(strings.ToUpper(tiles), "?", " ", -1))
+			resolved.Swap = true
+		}
+		return resolved, nil
+	}
+
+	fields := strings.Fields(move)
+	if len(fields) != 2 {
+		return j, errors.New("Move '" + move + "' must be a coordinate and a word, such as 8H WORD")
+	}
+
+	m, err := sb.moveRequest(fields[0], fields[1])
+	if err != nil {
+		return j, err
+	}
+	resolved.StartPos, resolved.EndPos = m.StartPos, m.EndPos
+	resolved.Tiles, resolved.Blanks = m.Tiles, m.Blanks
+	return resolved, nil
+}
+
+// replayPlay lays the tiles of a play from the history on the board, and
+// returns the squares they were placed on
+func (sb *ScrabbleBoard) replayPlay(e GameEvent) ([]SquareCoordinate, error) {
+	if e.Start == nil || e.End == nil || !e.Start.onBoard() || !e.End.onBoard() {
+		return nil, errors.New("Play has no position")
+	}
+
+	dr, dc := 0, 1
+	if e.Start.Col == e.End.Col && e.Start.Row < e.End.Row {
+		dr, dc = 1, 0
+	}
+
+	var squares []SquareCoordinate
+	for sc := *e.Start; sc.onBoard(); sc = (SquareCoordinate{Row: sc.Row + dr, Col: sc.Col + dc}) {
+		if !sb.occupied(sc) {
+			squares = append(squares, sc)
+		}
+		if sc == *e.End {
+			break
+		}
+	}
+	if len(squares) == 0 || len(squares) != len(e.Tiles) {
+		return nil, errors.New("Play does not match the board")
+	}
+
+	for i, sc := range squares {
+		tile := tiles[e.Tiles[i]]
+		if l := e.Tiles[i]; l >= 'a' && l <= 'z' {
+			tile = Tile{Letter: l - 'a' + 'A'}
+		}
+		tile.Count = 0
+		sb[sc.Row][sc.Col].Tile = tile
+	}
+
+	return squares, nil
+}
@@ -0,0 +1,97 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMoveNotationRoundTrip(t *testing.T) {
+	board := initializedBoard
+
+	for _, move := range []string{
+		"8H QUIXOTE",
+		"J6 SH(I)p",
+		"8H (QUIXOTE)S",
+		"6H LA(S)T",
+		"J5 a(SHIp)",
+	} {
+		j, err := board.resolveMove(GamePlayRequest{Move: move})
+		if err != nil {
+			t.Fatalf("Could not parse %q: %v", move, err)
+		}
+
+		p, err := board.evaluatePlay(j)
+		if err != nil {
+			t.Fatalf("Could not play %q: %v", move, err)
+		}
+		before := board
+		board.place(p)
+
+		if got := board.moveNotation(p.squares); got != move {
+			t.Errorf("Play parsed from %q is written as %q", move, got)
+		}
+
+		// The move parses back to the same request on the board it was played on
+		again, err := before.resolveMove(GamePlayRequest{Move: board.moveNotation(p.squares)})
+		if err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(again, j) {
+			t.Errorf("Round trip of %q gave %+v, expected %+v", move, again, j)
+		}
+	}
+}
+
+func TestParseMove(t *testing.T) {
+	board := initializedBoard
+	for i, l := range []byte("CAT") {
+		board[7][7+i].Tile = tiles[l]
+	}
+
+	j, err := board.resolveMove(GamePlayRequest{Move: "8h catS"})
+	if err != nil {
+		t.Fatal(err)
+	} else if j.StartPos != (SquareCoordinate{Row: 7, Col: 10}) || j.EndPos != j.StartPos || string(j.Tiles) != "S" {
+		t.Errorf("Letters on the board were not played through: %+v", j)
+	}
+
+	j, err = board.resolveMove(GamePlayRequest{Move: "8H ...s"})
+	if err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(j.Tiles, []byte(" ")) || !bytes.Equal(j.Blanks, []byte("S")) {
+		t.Errorf("Dots were not played through or blank not read: %+v", j)
+	}
+
+	j, err = board.resolveMove(GamePlayRequest{Move: "-"})
+	if err != nil || j.Swap || len(j.Tiles) != 0 {
+		t.Errorf("Dash was not read as a pass: %+v, %v", j, err)
+	}
+	j, err = board.resolveMove(GamePlayRequest{Move: "-qz?"})
+	if err != nil || !j.Swap || string(j.Tiles) != "QZ " {
+		t.Errorf("Dash and tiles were not read as a swap: %+v, %v", j, err)
+	}
+
+	for _, move := range []string{"8H", "Z9 WORD", "16A WORD", "8M WORDS", "8H (DOG)S", "8H CAT", "H8 W0RD"} {
+		if _, err := board.resolveMove(GamePlayRequest{Move: move}); err == nil {
+			t.Errorf("Invalid move %q was accepted", move)
+		}
+	}
+}
+
+func TestPlayInNotation(t *testing.T) {
+	sg := createScrabbleGame()
+	first, _ := sg.addPlayer("Ann")
+	sg.addPlayer("Ben")
+	sg.Players[first].Tiles = []byte("DOG ERS")
+
+	if err := sg.executePlay(GamePlayRequest{PlayerID: first, Move: "H7 DOgS"}); err != nil {
+		t.Fatal(err)
+	}
+
+	events := sg.history.list()
+	if len(events) != 1 || events[0].Move != "H7 DOgS" {
+		t.Errorf("Play was not recorded in notation: %+v", events)
+	} else if string(sg.Players[first].Tiles[:3]) != "GER" {
+		t.Errorf("Tiles left in rack are %q", sg.Players[first].Tiles)
+	}
+}
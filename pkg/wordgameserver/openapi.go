@@ -0,0 +1,19 @@
+package wordgameserver
+
+import (
+	_ "embed" // for the OpenAPI document
+	"net/http"
+)
+
+// openAPI describes every endpoint of the HTTP API. Tests check it against the
+// router and the request and response types, so it must be updated with them.
+//
+//go:embed openapi.json
+var openAPI []byte
+
+// openAPIHandler serves the OpenAPI document
+func openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(openAPI)
+}
@@ -0,0 +1,316 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// openAPISchema is the part of an OpenAPI schema the tests check
+type openAPISchema struct {
+	Ref                  string                   `json:"$ref"`
+	Type                 string                   `json:"type"`
+	Format               string                   `json:"format"`
+	Items                *openAPISchema           `json:"items"`
+	Properties           map[string]openAPISchema `json:"properties"`
+	AdditionalProperties *openAPISchema           `json:"additionalProperties"`
+}
+
+// openAPIDoc is the part of the OpenAPI document the tests check
+type openAPIDoc struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas   map[string]openAPISchema   `json:"schemas"`
+		Responses map[string]json.RawMessage `json:"responses"`
+	} `json:"components"`
+}
+
+// openAPITypes is the type described by each schema in the document
+var openAPITypes = map[string]interface{}{
+	"GeneralGameRequest":  GeneralGameRequest{},
+	"GameRules":           GameRules{},
+	"SquareCoordinate":    SquareCoordinate{},
+	"GridTile":            GridTile{},
+	"GamePlayRequest":     GamePlayRequest{},
+	"Tile":                Tile{},
+	"Square":              Square{},
+	"ScrabbleBoard":       ScrabbleBoard{},
+	"Player":              Player{},
+	"TeamScore":           TeamScore{},
+	"GameStateResponse":   GameStateResponse{},
+	"InboxRequest":        InboxRequest{},
+	"InboxEntry":          InboxEntry{},
+	"UnseenTilesResponse": UnseenTilesResponse{},
+	"TileDraw":            TileDraw{},
+	"GameEvent":           GameEvent{},
+	"ChatRequest":         ChatRequest{},
+	"ChatMessage":         ChatMessage{},
+	"LobbyGame":           LobbyGame{},
+	"MatchRequest":        MatchRequest{},
+	"Move":                Move{},
+	"PuzzleRequest":       PuzzleRequest{},
+	"Puzzle":              Puzzle{},
+	"PuzzleGrade":         PuzzleGrade{},
+	"WordLookupRequest":   WordLookupRequest{},
+	"WordLookupResponse":  WordLookupResponse{},
+	"StudyRequest":        StudyRequest{},
+	"StudyQuestion":       StudyQuestion{},
+	"StudyResult":         StudyResult{},
+	"CardboxSummary":      CardboxSummary{},
+	"SpectatorUpdate":     SpectatorUpdate{},
+	"ErrorResponse":       ErrorResponse{},
+	"ErrorDetail":         ErrorDetail{},
+	"GameResource":        GameResource{},
+}
+
+func loadOpenAPI(t *testing.T) openAPIDoc {
+	t.Helper()
+
+	var doc openAPIDoc
+	if err := json.Unmarshal(openAPI, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	newRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status %d", w.Code)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["openapi"] != "3.0.3" {
+		t.Errorf("Document is for OpenAPI %v", doc["openapi"])
+	}
+}
+
+// TestOpenAPIRoutes checks that every endpoint is documented, with the methods
+// it is restricted to, and that nothing else is
+func TestOpenAPIRoutes(t *testing.T) {
+	doc := loadOpenAPI(t)
+	routed := make(map[string]bool)
+
+	err := newRouter().Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil || path == "/" {
+			return nil // the browser client
+		} else if route.GetHandler() == nil {
+			return nil // a prefix for the routes of a subrouter
+		}
+
+		ops, ok := doc.Paths[path]
+		if !ok {
+			t.Errorf("Route %s is not documented", path)
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			routed[path] = true
+			return nil // any method is accepted
+		}
+		for _, m := range methods {
+			routed[path+" "+strings.ToLower(m)] = true
+			if _, ok := ops[strings.ToLower(m)]; !ok {
+				t.Errorf("%s %s is not documented", m, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			if !routed[path] && !routed[path+" "+method] {
+				t.Errorf("%s %s is documented but not routed", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
+
+// TestOpenAPIRefs checks that every reference in the document resolves
+func TestOpenAPIRefs(t *testing.T) {
+	doc := loadOpenAPI(t)
+
+	var raw interface{}
+	if err := json.Unmarshal(openAPI, &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				if ref, ok := child.(string); ok && k == "$ref" {
+					var found bool
+					switch {
+					case strings.HasPrefix(ref, "#/components/schemas/"):
+						_, found = doc.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+					case strings.HasPrefix(ref, "#/components/responses/"):
+						_, found = doc.Components.Responses[strings.TrimPrefix(ref, "#/components/responses/")]
+					}
+					if !found {
+						t.Errorf("Reference %s does not resolve", ref)
+					}
+				}
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(raw)
+}
+
+// TestOpenAPISchemas checks that each schema has the same fields as the type
+// it describes, with matching types
+func TestOpenAPISchemas(t *testing.T) {
+	doc := loadOpenAPI(t)
+
+	for name := range doc.Components.Schemas {
+		if _, ok := openAPITypes[name]; !ok {
+			t.Errorf("Schema %s has no type", name)
+		}
+	}
+	for name, v := range openAPITypes {
+		schema, ok := doc.Components.Schemas[name]
+		if !ok {
+			t.Errorf("Type %s has no schema", name)
+			continue
+		}
+		checkSchema(t, name, schema, reflect.TypeOf(v), true)
+	}
+}
+
+// checkSchema compares a schema with a type. Named types are only expanded at
+// the top level, and are otherwise expected to be references to their schema.
+func checkSchema(t *testing.T, path string, schema openAPISchema, typ reflect.Type, top bool) {
+	t.Helper()
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		if v, ok := openAPITypes[name]; !ok || reflect.TypeOf(v) != typ {
+			t.Errorf("%s refers to %s, but is %v", path, name, typ)
+		}
+		return
+	}
+	if !top {
+		for name, v := range openAPITypes {
+			if reflect.TypeOf(v) == typ {
+				t.Errorf("%s should refer to %s", path, name)
+				return
+			}
+		}
+	}
+
+	want := func(typeName, format string) {
+		if schema.Type != typeName || schema.Format != format {
+			t.Errorf("%s is %s %s, expected %s %s", path, schema.Type, schema.Format, typeName, format)
+		}
+	}
+
+	switch {
+	case typ == reflect.TypeOf(uuid.UUID{}):
+		want("string", "uuid")
+	case typ == reflect.TypeOf(time.Time{}):
+		want("string", "date-time")
+	case typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8:
+		want("string", "byte")
+	case typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array:
+		if schema.Type != "array" || schema.Items == nil {
+			t.Errorf("%s is %s, expected array", path, schema.Type)
+			return
+		}
+		checkSchema(t, path+"[]", *schema.Items, typ.Elem(), false)
+	case typ.Kind() == reflect.Map:
+		if schema.Type != "object" || schema.AdditionalProperties == nil {
+			t.Errorf("%s is %s, expected a map", path, schema.Type)
+			return
+		}
+		checkSchema(t, path+"{}", *schema.AdditionalProperties, typ.Elem(), false)
+	case typ.Kind() == reflect.Struct:
+		if schema.Type != "object" {
+			t.Errorf("%s is %s, expected object", path, schema.Type)
+		}
+		fields := jsonFields(typ)
+		var names []string
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, ok := schema.Properties[name]
+			if !ok {
+				t.Errorf("%s has no property %s", path, name)
+				continue
+			}
+			checkSchema(t, path+"."+name, prop, fields[name], false)
+		}
+		for name := range schema.Properties {
+			if _, ok := fields[name]; !ok {
+				t.Errorf("%s has property %s, which is not encoded", path, name)
+			}
+		}
+	case typ.Kind() == reflect.String:
+		want("string", schema.Format)
+	case typ.Kind() == reflect.Bool:
+		want("boolean", "")
+	case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Uint64:
+		want("integer", schema.Format)
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		want("number", "")
+	default:
+		t.Errorf("%s has unexpected type %v", path, typ)
+	}
+}
+
+// jsonFields returns the type of each field a struct is encoded with, by JSON
+// name. Untagged embedded structs are flattened, as encoding/json does.
+func jsonFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag := f.Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+
+		switch {
+		case tag == "-":
+			continue
+		case f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct:
+			for n, t := range jsonFields(f.Type) {
+				fields[n] = t
+			}
+			continue
+		case f.PkgPath != "":
+			continue // unexported
+		case name == "":
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+
+	return fields
+}
@@ -0,0 +1,112 @@
+package wordgameserver
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Turn order modes that can be chosen in a game's rules
+const (
+	turnOrderJoin   = "join"   // players move in the order they joined
+	turnOrderRandom = "random" // players are shuffled
+	turnOrderDraw   = "draw"   // players draw tiles and the closest to A goes first
+)
+
+// drawRank ranks a drawn tile, where lower goes first. Blanks beat every
+// letter.
+func drawRank(t byte) int {
+	if t == ' ' {
+		return 0
+	}
+	return int(t-'A') + 1
+}
+
+// validateTurnOrder checks that the rules name a known turn order mode
+func (r GameRules) validateTurnOrder() error {
+	switch r.TurnOrder {
+	case "", turnOrderJoin, turnOrderRandom, turnOrderDraw:
+		return nil
+	}
+	return newError(CodeInvalidRules, "Unknown turn order '"+r.TurnOrder+"'")
+}
+
+// orderPlayers renumbers the players according to the rules' turn order mode,
+// which must already have been validated. Players keep the order they joined
+// in by default.
+func (sg *ScrabbleGame) orderPlayers() {
+	playerList := sg.playerList()
+
+	switch sg.Rules.TurnOrder {
+	case turnOrderRandom:
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		r.Shuffle(len(playerList), func(i, j int) {
+			playerList[i], playerList[j] = playerList[j], playerList[i]
+		})
+	case turnOrderDraw:
+		first := sg.drawForFirst(playerList)
+		playerList = append(playerList[first:], playerList[:first]...)
+	default:
+		return
+	}
+
+	for i, p := range playerList {
+		p.Number = i
+	}
+}
+
+// drawForFirst has each player draw a tile from the bag, repeating the draw
+// between tied players, and records the draws in the game history. The tiles
+// are returned to the bag and the index of the winner in the player list is
+// returned.
+func (sg *ScrabbleGame) drawForFirst(playerList []*Player) int {
+	var draws []TileDraw
+	var drawn TileBag
+
+	tied := make([]int, len(playerList))
+	for i := range tied {
+		tied[i] = i
+	}
+
+	for round := 1; len(tied) > 1; round++ {
+		best := -1
+		var leaders []int
+		for _, i := range tied {
+			t := sg.TileBag[0]
+			sg.TileBag = sg.TileBag[1:]
+			drawn = append(drawn, t)
+
+			draws = append(draws, TileDraw{
+				Round:  round,
+				Player: playerList[i].Name,
+				Letter: string(t),
+			})
+
+			switch rank := drawRank(t); {
+			case best == -1 || rank < best:
+				best = rank
+				leaders = []int{i}
+			case rank == best:
+				leaders = append(leaders, i)
+			}
+		}
+		tied = leaders
+
+		// Return the drawn tiles if the bag runs too low for another round
+		if len(sg.TileBag) < len(tied) {
+			sg.TileBag = append(sg.TileBag, drawn...)
+			drawn = nil
+			sg.TileBag.shuffle()
+		}
+	}
+
+	sg.TileBag = append(sg.TileBag, drawn...)
+	sg.TileBag.shuffle()
+
+	sg.history.record(GameEvent{
+		Type:   "draw",
+		Player: playerList[tied[0]].Name,
+		Draws:  draws,
+	})
+
+	return tied[0]
+}
@@ -0,0 +1,70 @@
+package wordgameserver
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDrawForFirst(t *testing.T) {
+	tests := []struct {
+		bag   TileBag
+		first int
+		order []int
+	}{
+		{bag: TileBag{'C', 'A', ' ', 'Z'}, first: 2, order: []int{2, 0, 1}},
+		{bag: TileBag{'A', 'A', 'B', 'E', 'D', 'Z'}, first: 1, order: []int{1, 2, 0}},
+	}
+
+	for _, test := range tests {
+		newGame := createScrabbleGame()
+		newGame.Rules.TurnOrder = turnOrderDraw
+		newGame.TileBag = append(TileBag{}, test.bag...)
+
+		playerIDs := make([]uuid.UUID, 3)
+		for i, name := range []string{"ashley1", "ashley2", "ashley3"} {
+			playerIDs[i], _ = newGame.addPlayer(name)
+		}
+
+		newGame.orderPlayers()
+
+		for number, joined := range test.order {
+			if n := newGame.Players[playerIDs[joined]].Number; n != number {
+				t.Errorf("Expected player %v to move at %v, moves at %v", joined, number, n)
+			}
+		}
+
+		if len(newGame.TileBag) != len(test.bag) {
+			t.Errorf("Drawn tiles were not returned to the bag")
+		}
+
+		events := newGame.history.list()
+		if len(events) != 1 || events[0].Type != "draw" {
+			t.Fatalf("Draw was not recorded in history: %v", events)
+		} else if events[0].Player != newGame.Players[playerIDs[test.first]].Name {
+			t.Errorf("History records %v going first", events[0].Player)
+		}
+	}
+}
+
+func TestRandomTurnOrder(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.TurnOrder = turnOrderRandom
+
+	for _, name := range []string{"ashley1", "ashley2", "ashley3", "ashley4"} {
+		newGame.addPlayer(name)
+	}
+
+	newGame.orderPlayers()
+
+	for i, p := range newGame.playerList() {
+		if p == nil || p.Number != i {
+			t.Fatal("Player numbers are not dense after shuffle")
+		}
+	}
+
+	newGame.Rules.TurnOrder = "alphabetical"
+	if err := newGame.start(); err == nil {
+		t.Fatal("Unknown turn order should fail")
+	}
+}
@@ -0,0 +1,339 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Correspondence games last for days, so once started they are saved after
+// every change and restored when the server starts, with their turn deadlines
+// armed again. Duplicate rounds are not saved, so duplicate games are not
+// either. Study cardboxes are saved in the same directory after every answer.
+
+var (
+	saveMu  sync.Mutex
+	saveDir string // directory games are saved in, empty if games are not saved
+)
+
+// cardboxFile is the file in the save directory that cardboxes are saved in.
+// It is kept in a directory of its own so it is never read as a game.
+var cardboxFile = filepath.Join("study", "cardboxes.json")
+
+// savedGame is the form a game is saved in
+type savedGame struct {
+	ID             uuid.UUID     `json:"id"`
+	HostID         uuid.UUID     `json:"host_id"`
+	Locked         bool          `json:"locked,omitempty"`
+	Rules          GameRules     `json:"rules"`
+	TurnCount      int           `json:"turn_count"`
+	Board          ScrabbleBoard `json:"board"`
+	TileBag        TileBag       `json:"tile_bag"`
+	Players        []savedPlayer `json:"players"` // in turn order
+	TurnDeadline   time.Time     `json:"turn_deadline"`
+	Winner         string        `json:"winner,omitempty"`
+	Finished       bool          `json:"finished,omitempty"`
+	ScorelessTurns int           `json:"scoreless_turns,omitempty"`
+	History        []savedEvent  `json:"history"`
+	Chat           []savedChat   `json:"chat,omitempty"`
+	Muted          []uuid.UUID   `json:"muted,omitempty"` // players who muted the chat
+}
+
+// savedPlayer is the form a player is saved in, including their ID and rack
+type savedPlayer struct {
+	ID              uuid.UUID     `json:"id"`
+	Name            string        `json:"name"`
+	Tiles           []byte        `json:"tiles"`
+	Score           int           `json:"score"`
+	TimeRemaining   time.Duration `json:"time_remaining,omitempty"`
+	OvertimeMinutes int           `json:"overtime_minutes,omitempty"`
+	Forfeited       bool          `json:"forfeited,omitempty"`
+	Team            int           `json:"team,omitempty"`
+}
+
+// savedEvent is the form an event is saved in, including whose rack it shows
+// and which team can see it
+type savedEvent struct {
+	GameEvent
+	Owner uuid.UUID `json:"owner"`
+	Team  int       `json:"team,omitempty"`
+}
+
+// savedCardbox is the form a user's cardbox for one dictionary is saved in
+type savedCardbox struct {
+	User       string               `json:"user"`
+	Dictionary string               `json:"dictionary"`
+	Cards      map[string]savedCard `json:"cards"` // keyed by alphagram
+}
+
+// savedCard is the form a card is saved in
+type savedCard struct {
+	Box       int       `json:"box"`
+	Due       time.Time `json:"due"`
+	Correct   int       `json:"correct,omitempty"`
+	Incorrect int       `json:"incorrect,omitempty"`
+}
+
+// savedChat is the form a chat message is saved in, including which team can
+// see it
+type savedChat struct {
+	ChatMessage
+	Team int `json:"team,omitempty"`
+}
+
+// LoadGames restores the games and cardboxes saved in the directory and saves
+// them there from then on. Games whose turn deadline passed while the server
+// was down have it handled as soon as they are restored.
+func LoadGames(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	games := make([]*ScrabbleGame, 0, len(paths))
+	for _, path := range paths {
+		sg, err := loadGame(path)
+		if err != nil {
+			return errors.Wrap(err, path)
+		}
+		games = append(games, sg)
+	}
+
+	boxes, err := loadCardboxes(filepath.Join(dir, cardboxFile))
+	if err != nil {
+		return errors.Wrap(err, cardboxFile)
+	}
+
+	studyMu.Lock()
+	for key, cb := range boxes {
+		cardboxes[key] = cb
+	}
+	studyMu.Unlock()
+
+	saveMu.Lock()
+	saveDir = dir
+	saveMu.Unlock()
+
+	serverMu.Lock()
+	defer serverMu.Unlock()
+
+	for _, sg := range games {
+		server.activeGames[sg.ID] = sg
+		go sg.resumeController()
+	}
+
+	return nil
+}
+
+// saved reports whether the game is kept across restarts
+func (sg *ScrabbleGame) saved() bool {
+	return sg.Rules.TurnDeadlineHours > 0 && !sg.Rules.Duplicate
+}
+
+// save writes the game to the save directory, if there is one. It is only
+// called by the state controller, which owns the state it reads apart from
+// the lock and the chat, which are read under their own locks. The file is
+// replaced in one step, so a crash never leaves half a game behind.
+func (sg *ScrabbleGame) save() {
+	saveMu.Lock()
+	dir := saveDir
+	saveMu.Unlock()
+
+	if dir == "" || !sg.saved() {
+		return
+	}
+
+	sg.Lock()
+	locked := sg.Locked
+	sg.Unlock()
+
+	g := savedGame{
+		ID:             sg.ID,
+		HostID:         sg.HostID,
+		Locked:         locked,
+		Rules:          sg.Rules,
+		TurnCount:      sg.TurnCount,
+		Board:          sg.Board,
+		TileBag:        sg.TileBag,
+		TurnDeadline:   sg.TurnDeadline,
+		Winner:         sg.Winner,
+		Finished:       sg.Finished,
+		ScorelessTurns: sg.scorelessTurns,
+	}
+	for _, p := range sg.playerList() {
+		g.Players = append(g.Players, savedPlayer{
+			ID:              p.ID,
+			Name:            p.Name,
+			Tiles:           p.Tiles,
+			Score:           p.Score,
+			TimeRemaining:   p.TimeRemaining,
+			OvertimeMinutes: p.overtimeMinutes,
+			Forfeited:       p.Forfeited,
+			Team:            p.Team,
+		})
+	}
+	for _, e := range sg.history.list() {
+		g.History = append(g.History, savedEvent{GameEvent: e, Owner: e.owner, Team: e.team})
+	}
+
+	sg.chat.Lock()
+	for _, m := range sg.chat.messages {
+		g.Chat = append(g.Chat, savedChat{ChatMessage: m, Team: m.team})
+	}
+	for id, muted := range sg.chat.muted {
+		if muted {
+			g.Muted = append(g.Muted, id)
+		}
+	}
+	sg.chat.Unlock()
+
+	if err := writeSaved(filepath.Join(dir, sg.ID.String()+".json"), g); err != nil {
+		log.Printf("Could not save game %v: %v", sg.ID, err)
+	}
+}
+
+// writeSaved writes the value to a temporary file and renames it over the
+// path
+func writeSaved(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadGame reads a saved game. The game is ready to resume, but not yet
+// registered with the server.
+func loadGame(path string) (*ScrabbleGame, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var g savedGame
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, err
+	} else if len(g.Players) < 2 {
+		return nil, errors.New("Saved game has too few players")
+	}
+
+	sg := createScrabbleGame()
+	sg.ID = g.ID
+	sg.HostID = g.HostID
+	sg.Rules = g.Rules
+	sg.TurnCount = g.TurnCount
+	sg.Board = g.Board
+	sg.TileBag = g.TileBag
+	sg.TurnDeadline = g.TurnDeadline
+	sg.Winner = g.Winner
+	sg.Finished = g.Finished
+	sg.scorelessTurns = g.ScorelessTurns
+	sg.Locked = g.Locked
+	sg.Active = true
+
+	for i, p := range g.Players {
+		sg.Players[p.ID] = &Player{
+			ID:              p.ID,
+			Name:            p.Name,
+			Number:          i,
+			Tiles:           append([]byte{}, p.Tiles...),
+			Score:           p.Score,
+			State:           make(chan GameStateResponse),
+			Play:            make(chan GameStateResponse),
+			TimeRemaining:   p.TimeRemaining,
+			overtimeMinutes: p.OvertimeMinutes,
+			Forfeited:       p.Forfeited,
+			Team:            p.Team,
+		}
+	}
+
+	for _, e := range g.History {
+		e.GameEvent.owner, e.GameEvent.team = e.Owner, e.Team
+		sg.history.record(e.GameEvent)
+	}
+
+	for _, m := range g.Chat {
+		m.ChatMessage.team = m.Team
+		sg.chat.messages = append(sg.chat.messages, m.ChatMessage)
+	}
+	for _, id := range g.Muted {
+		sg.chat.muted[id] = true
+	}
+
+	return sg, nil
+}
+
+// saveCardboxes writes every cardbox to the save directory, if there is one.
+// The caller must hold studyMu.
+func saveCardboxes() {
+	saveMu.Lock()
+	dir := saveDir
+	saveMu.Unlock()
+
+	if dir == "" {
+		return
+	}
+
+	boxes := make([]savedCardbox, 0, len(cardboxes))
+	for key, cb := range cardboxes {
+		parts := strings.SplitN(key, "\x00", 2)
+		box := savedCardbox{
+			User:       parts[0],
+			Dictionary: parts[1],
+			Cards:      make(map[string]savedCard, len(cb)),
+		}
+		for a, c := range cb {
+			box.Cards[a] = savedCard{Box: c.box, Due: c.due, Correct: c.correct, Incorrect: c.incorrect}
+		}
+		boxes = append(boxes, box)
+	}
+
+	path := filepath.Join(dir, cardboxFile)
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err == nil {
+		err = writeSaved(path, boxes)
+	}
+	if err != nil {
+		log.Printf("Could not save cardboxes: %v", err)
+	}
+}
+
+// loadCardboxes reads saved cardboxes. A missing file means none were saved.
+func loadCardboxes(path string) (map[string]cardbox, error) {
+	boxes := make(map[string]cardbox)
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return boxes, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var saved []savedCardbox
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+
+	for _, box := range saved {
+		cb := make(cardbox, len(box.Cards))
+		for a, c := range box.Cards {
+			cb[a] = &studyCard{box: c.Box, due: c.Due, correct: c.Correct, incorrect: c.Incorrect}
+		}
+		boxes[cardboxKey(box.User, box.Dictionary)] = cb
+	}
+
+	return boxes, nil
+}
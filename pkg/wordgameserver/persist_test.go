@@ -0,0 +1,185 @@
+package wordgameserver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// testSaveDir saves games in a temporary directory until the test ends
+func testSaveDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "wordgame")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		saveMu.Lock()
+		saveDir = ""
+		saveMu.Unlock()
+		os.RemoveAll(dir)
+	})
+
+	if err := LoadGames(dir); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestSaveGame(t *testing.T) {
+	dir := testSaveDir(t)
+
+	newGame := createScrabbleGame()
+	newGame.Rules.TurnDeadlineHours = 24
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+	newGame.TileBag = append(TileBag("CATERSZCATERSZ"), newGame.TileBag...)
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Chat and mutes are saved with the next change to the game
+	if _, err := newGame.postChat(ChatRequest{GameID: newGame.ID, PlayerID: &playerIDs[0], Text: "good luck"}); err != nil {
+		t.Fatal(err)
+	}
+	newGame.chat.setMuted(playerIDs[1], true)
+
+	_, err := newGame.request(GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: playerIDs[0],
+		StartPos: SquareCoordinate{Row: 7, Col: 6},
+		EndPos:   SquareCoordinate{Row: 7, Col: 8},
+		Tiles:    []byte("CAT"),
+		Play:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Restoring the saved game keeps the board, racks, scores and deadline
+	restored, err := loadGame(filepath.Join(dir, newGame.ID.String()+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go restored.resumeController()
+
+	after, err := restored.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(before.Board, after.Board) {
+		t.Fatal("Board was not restored")
+	} else if string(before.PlayerTiles) != string(after.PlayerTiles) || before.TilesRemaining != after.TilesRemaining {
+		t.Fatal("Tiles were not restored")
+	} else if before.PlayerTurn != after.PlayerTurn {
+		t.Fatalf("Turn %v was not restored as %v", after.PlayerTurn, before.PlayerTurn)
+	} else if !restored.TurnDeadline.Equal(newGame.TurnDeadline) {
+		t.Fatalf("Deadline %v was not restored as %v", restored.TurnDeadline, newGame.TurnDeadline)
+	}
+
+	for i, p := range after.Players {
+		if p.ID != before.Players[i].ID || p.Name != before.Players[i].Name || p.Score != before.Players[i].Score {
+			t.Fatalf("Player %v was not restored: %+v, expected %+v", i, *p, *before.Players[i])
+		}
+	}
+
+	history, err := restored.visibleHistory(&playerIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	} else if len(history) != 2 || history[1].Type != "play" || history[1].Rack == "" {
+		t.Fatalf("History was not restored with the player's rack: %v", history)
+	}
+
+	if messages := restored.chat.since(0, 0); len(messages) != 1 || messages[0].Text != "good luck" {
+		t.Fatalf("Chat was not restored: %v", messages)
+	} else if !restored.chat.isMuted(playerIDs[1]) || restored.chat.isMuted(playerIDs[0]) {
+		t.Fatal("Mutes were not restored")
+	}
+}
+
+func TestRestoredDeadline(t *testing.T) {
+	dir := testSaveDir(t)
+
+	newGame, playerIDs := startCorrespondenceGame(t, GameRules{TurnDeadlineHours: 1000})
+	if _, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[0]}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The deadline passes while the server is down
+	path := filepath.Join(dir, newGame.ID.String()+".json")
+	restored, err := loadGame(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored.TurnDeadline = time.Now().Add(-time.Hour)
+
+	serverMu.Lock()
+	server.activeGames[restored.ID] = restored
+	serverMu.Unlock()
+	go restored.resumeController()
+
+	for i := 0; ; i++ {
+		entries := inbox(playerIDs[1:])
+		if len(entries) == 1 && entries[0].Deadline.After(time.Now()) {
+			break
+		} else if i == 100 {
+			t.Fatal("Turn did not pass when the restored deadline had passed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The pass is saved too
+	saved, err := loadGame(path)
+	if err != nil {
+		t.Fatal(err)
+	} else if saved.TurnCount != 1 {
+		t.Fatalf("Expected the pass to be saved, turn count is %v", saved.TurnCount)
+	}
+}
+
+func TestSaveCardboxes(t *testing.T) {
+	dir := testSaveDir(t)
+	d := testDictionary(t)
+	now := time.Now()
+
+	j := StudyRequest{User: "TestSaveCardboxes", Alphagram: "ACT", Words: []string{"act", "cat", "tac"}}
+	r, err := studyAnswer(j, d, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Forget the card, as a restart would
+	key := cardboxKey(j.User, d.Name)
+	studyMu.Lock()
+	delete(cardboxes, key)
+	studyMu.Unlock()
+
+	// The cardboxes are restored without being mistaken for a saved game
+	if err := LoadGames(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	studyMu.Lock()
+	c, ok := cardboxes[key]["ACT"]
+	studyMu.Unlock()
+
+	if !ok {
+		t.Fatal("Card was not restored")
+	} else if c.box != r.Box || !c.due.Equal(r.Due) || c.correct != 1 || c.incorrect != 0 {
+		t.Fatalf("Card was restored as %+v, expected box %v due %v", *c, r.Box, r.Due)
+	}
+}
@@ -1,29 +1,115 @@
 package wordgameserver
 
-import "errors"
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// scorelessTurnLimit is the number of passes and swaps in a row after which
+// the game ends, so that a game nobody can finish does not go on forever
+const scorelessTurnLimit = 6
 
 func (sg *ScrabbleGame) executePlay(j GamePlayRequest) error {
+	if sg.Finished {
+		return errGameOver
+	}
+
 	playerTurn := sg.TurnCount % len(sg.Players)
 	if playerTurn != sg.Players[j.PlayerID].Number {
-		return errors.New("Playing out of turn. Expected Player " + string(playerTurn))
+		return newError(CodeNotYourTurn, "Playing out of turn. Expected Player "+strconv.Itoa(playerTurn))
+	}
+
+	j, err := sg.Board.resolveMove(j)
+	if err != nil {
+		return err
 	} else if len(j.Tiles) > 7 {
-		return errors.New("Cannot play more than 7 tiles")
+		return newError(CodeInvalidPlacement, "Cannot play more than 7 tiles")
 	}
 
 	if j.Swap {
 		return sg.swapTiles(j)
+	} else if len(j.Tiles) == 0 {
+		return sg.pass(j)
 	}
 
+	return sg.playTiles(j)
+}
+
+// playTiles places the player's tiles on the board, adds the score of the
+// words formed, and deals the player new tiles
+func (sg *ScrabbleGame) playTiles(j GamePlayRequest) error {
+	cp := sg.Players[j.PlayerID]
+	rack := string(cp.Tiles)
+
+	// Remove tiles from player's hand, putting them back if the play fails
+	if err := removeTiles(cp, j.Tiles); err != nil {
+		return err
+	}
+
+	p, err := sg.Board.evaluatePlay(j)
+	if err == nil {
+		err = sg.checkWords(p.words)
+	}
+	if err != nil {
+		cp.Tiles = []byte(rack)
+		return err
+	}
+
+	sg.Board.place(p)
+	cp.Score += p.score
+	sg.scorelessTurns = 0
+	move := sg.Board.moveNotation(p.squares)
+
+	// Deal new tiles to player, as many as are left in the bag
+	dealCount := len(j.Tiles)
+	if dealCount > len(sg.TileBag) {
+		dealCount = len(sg.TileBag)
+	}
+	dealTiles(cp, &sg.TileBag, dealCount)
+
+	sg.history.record(GameEvent{
+		Type:   "play",
+		Turn:   sg.TurnCount,
+		Player: cp.Name,
+		Rack:   rack,
+		owner:  cp.ID,
+		Start:  &j.StartPos,
+		End:    &j.EndPos,
+		Tiles:  p.played(),
+		Move:   move,
+		Words:  p.words,
+		Score:  p.score,
+	})
+
+	return nil
+}
+
+// pass ends the player's turn without playing
+func (sg *ScrabbleGame) pass(j GamePlayRequest) error {
+	cp := sg.Players[j.PlayerID]
+	sg.scorelessTurns++
+	sg.history.record(GameEvent{
+		Type:   "pass",
+		Turn:   sg.TurnCount,
+		Player: cp.Name,
+		Rack:   string(cp.Tiles),
+		owner:  cp.ID,
+	})
+
 	return nil
 }
 
 func (sg *ScrabbleGame) swapTiles(j GamePlayRequest) error {
 	if len(j.Tiles) > len(sg.TileBag) {
-		return errors.New("Not enough tiles available for swap")
+		return newError(CodeNotEnoughTiles, "Not enough tiles available for swap")
 	}
 
 	// Remove tiles from player's hand
 	cp := sg.Players[j.PlayerID]
+	rack := string(cp.Tiles)
 	err := removeTiles(cp, j.Tiles)
 	if err != nil {
 		return err
@@ -35,6 +121,161 @@ func (sg *ScrabbleGame) swapTiles(j GamePlayRequest) error {
 	// Add swapped tiles to bag and shuffle
 	sg.TileBag = append(sg.TileBag, j.Tiles...)
 	sg.TileBag.shuffle()
+	sg.scorelessTurns++
+
+	sg.history.record(GameEvent{
+		Type:   "swap",
+		Turn:   sg.TurnCount,
+		Player: cp.Name,
+		Rack:   rack,
+		owner:  cp.ID,
+		Tiles:  string(j.Tiles),
+		Count:  len(j.Tiles),
+	})
 
 	return nil
 }
+
+// finishTurn ends the game if the player who just moved went out with the bag
+// empty, or if nobody has scored for too many turns, and otherwise passes the
+// turn on
+func (sg *ScrabbleGame) finishTurn(now time.Time, playerList []*Player) {
+	cp := sg.currentPlayer()
+	switch {
+	case len(sg.TileBag) == 0 && len(cp.Tiles) == 0:
+		sg.endGame(cp, playerList)
+	case sg.scorelessTurns >= scorelessTurnLimit:
+		sg.endGame(nil, playerList)
+	default:
+		sg.advanceTurn(now)
+	}
+}
+
+// forfeit takes the player out of the game. The game ends once fewer than two
+// players, or two teams in team games, are left in it, and otherwise the turn
+// passes on.
+func (sg *ScrabbleGame) forfeit(p *Player, now time.Time) {
+	p.Forfeited = true
+
+	playerList := sg.playerList()
+	if len(sidesLeft(playerList)) < 2 {
+		sg.endGame(nil, playerList)
+		return
+	}
+	sg.advanceTurn(now)
+}
+
+// sidesLeft returns the players, or the teams in team games, who have not
+// forfeited. Players are keyed by their number and teams by their negated
+// team number.
+func sidesLeft(playerList []*Player) map[int]bool {
+	sides := make(map[int]bool)
+	for _, p := range playerList {
+		switch {
+		case p.Forfeited:
+		case p.Team != 0:
+			sides[-p.Team] = true
+		default:
+			sides[p.Number] = true
+		}
+	}
+	return sides
+}
+
+// endGame settles the tiles left on the racks and names the winner. Every
+// player loses the value of their own rack, and the player who went out, if
+// any, gains the value of their opponents' racks. A teammate's rack is not an
+// opponent's, so in team games it only counts against the team.
+func (sg *ScrabbleGame) endGame(out *Player, playerList []*Player) {
+	for _, p := range playerList {
+		if p == out || len(p.Tiles) == 0 {
+			continue
+		}
+
+		value := rackValue(p.Tiles)
+		p.Score -= value
+		sg.history.record(GameEvent{
+			Type:   "endrack",
+			Turn:   sg.TurnCount,
+			Player: p.Name,
+			Tiles:  string(p.Tiles),
+			Score:  -value,
+		})
+
+		if out != nil && (out.Team == 0 || out.Team != p.Team) {
+			out.Score += value
+			sg.history.record(GameEvent{
+				Type:   "endrack",
+				Turn:   sg.TurnCount,
+				Player: out.Name,
+				Tiles:  string(p.Tiles),
+				Score:  value,
+			})
+		}
+	}
+
+	sg.Winner = winner(playerList)
+	sg.TurnDeadline = time.Time{}
+	if sg.clock.timer != nil {
+		sg.clock.timer.Stop()
+	}
+	if sg.deadline.timer != nil {
+		sg.deadline.timer.Stop()
+	}
+
+	sg.Lock()
+	sg.Finished = true
+	sg.upNext = uuid.Nil
+	sg.Unlock()
+}
+
+// rackValue totals the points of the tiles on a rack
+func rackValue(rack []byte) int {
+	value := 0
+	for _, t := range rack {
+		value += tiles[t].Value
+	}
+	return value
+}
+
+// winner names the player with the highest score, or the players of the team
+// with the highest score in team games. Everyone tied for the lead is named.
+// Players and teams who forfeited cannot win, unless nobody is left.
+func winner(playerList []*Player) string {
+	type side struct {
+		names []string
+		score int
+	}
+
+	left := sidesLeft(playerList)
+	inPlay := func(key int) bool {
+		return len(left) == 0 || left[key]
+	}
+
+	var sides []side
+	if teams := teamScores(playerList); len(teams) > 0 {
+		for _, t := range teams {
+			if inPlay(-t.Team) {
+				sides = append(sides, side{t.Players, t.Score})
+			}
+		}
+	} else {
+		for _, p := range playerList {
+			if inPlay(p.Number) {
+				sides = append(sides, side{[]string{p.Name}, p.Score})
+			}
+		}
+	}
+
+	var names []string
+	best := 0
+	for i, s := range sides {
+		if i == 0 || s.score > best {
+			names, best = nil, s.score
+		}
+		if s.score == best {
+			names = append(names, s.names...)
+		}
+	}
+	return strings.Join(names, " & ")
+}
@@ -0,0 +1,44 @@
+package wordgameserver
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestScorelessTurns(t *testing.T) {
+	newGame := createScrabbleGame()
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	var s GameStateResponse
+	for turn := 0; turn < scorelessTurnLimit; turn++ {
+		if s.Winner != "" {
+			t.Fatalf("Game ended after %v passes", turn)
+		}
+
+		var err error
+		s, err = newGame.request(GamePlayRequest{
+			GameID:   newGame.ID,
+			PlayerID: playerIDs[turn%2],
+			Play:     true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Both players lose their racks, so neither can be said to have gone out
+	if s.Winner == "" {
+		t.Fatal("Game did not end after too many scoreless turns")
+	} else if s.Players[0].Score >= 0 || s.Players[1].Score >= 0 {
+		t.Fatalf("Racks were not deducted: %v", s.Players)
+	}
+}
@@ -0,0 +1,297 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Range of turns played from an empty board to reach a puzzle's position
+const (
+	minPuzzleTurns = 4
+	maxPuzzleTurns = 8
+)
+
+// puzzleWindow is how far a puzzle's date may be from today. It allows for
+// users whose day starts or ends before the server's, and keeps the cache of
+// puzzles from growing with every date asked for.
+const puzzleWindow = 24 * time.Hour
+
+// puzzleChoices is the number of top moves a turn is picked from while
+// setting up a puzzle, so positions are not all built from greedy plays
+const puzzleChoices = 3
+
+// Puzzle is a "find the best play" position: a board and a rack to play
+type Puzzle struct {
+	Date       string        `json:"date"`
+	Dictionary string        `json:"dictionary"`
+	Board      ScrabbleBoard `json:"board"`
+	Rack       []byte        `json:"rack"`
+	moves      []Move        // every play of the rack, best first
+}
+
+// PuzzleRequest is the format of the request a client sends to fetch a daily
+// puzzle, or to have an answer graded
+type PuzzleRequest struct {
+	Date       string           `json:"date,omitempty"` // day of the puzzle as YYYY-MM-DD within a day of today, today if not set
+	Dictionary string           `json:"dictionary"`
+	Answer     *GamePlayRequest `json:"answer,omitempty"`
+}
+
+// PuzzleGrade is the result of grading an answer to a puzzle
+type PuzzleGrade struct {
+	Score     int     `json:"score"`      // score of the answer
+	BestScore int     `json:"best_score"` // score of the top move
+	Rank      int     `json:"rank"`       // position of the answer's score among all plays, 1 for the top
+	Credit    float64 `json:"credit"`     // answer's score as a fraction of the top move's
+	Best      Move    `json:"best"`       // top move found by the move generator
+}
+
+var (
+	puzzlesMu sync.Mutex
+	puzzles   = make(map[string]*Puzzle)
+)
+
+// dailyPuzzle returns the puzzle for the date and dictionary, generating it
+// the first time it is asked for. Only dates within a day of now are served,
+// and puzzles for other dates are dropped from the cache.
+func dailyPuzzle(date string, d *Dictionary, now time.Time) (*Puzzle, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, errors.New("Date must be in the format YYYY-MM-DD")
+	} else if !puzzleDateAllowed(day, now) {
+		return nil, errors.New("Puzzles are only available for dates within a day of today")
+	}
+
+	key := date + "/" + d.Name
+
+	puzzlesMu.Lock()
+	for k, p := range puzzles {
+		if day, err := time.Parse("2006-01-02", p.Date); err != nil || !puzzleDateAllowed(day, now) {
+			delete(puzzles, k)
+		}
+	}
+	p, ok := puzzles[key]
+	puzzlesMu.Unlock()
+
+	if ok {
+		return p, nil
+	}
+
+	// Generating takes a while, so other puzzles are served in the meantime.
+	// The same date always generates the same puzzle, so if another request
+	// stored it first, theirs is kept.
+	p, err = generatePuzzle(date, d)
+	if err != nil {
+		return nil, err
+	}
+
+	puzzlesMu.Lock()
+	defer puzzlesMu.Unlock()
+
+	if stored, ok := puzzles[key]; ok {
+		return stored, nil
+	}
+	puzzles[key] = p
+
+	return p, nil
+}
+
+// puzzleDateAllowed reports whether the day is within the puzzle window of the
+// day it is now
+func puzzleDateAllowed(day, now time.Time) bool {
+	diff := day.Sub(now.UTC().Truncate(24 * time.Hour))
+	return diff >= -puzzleWindow && diff <= puzzleWindow
+}
+
+// generatePuzzle plays a few turns from an empty board to reach a mid-game
+// position, then finds every play of the next rack. The tiles drawn and the
+// moves picked depend only on the date and dictionary, so every user gets the
+// same puzzle.
+func generatePuzzle(date string, d *Dictionary) (*Puzzle, error) {
+	h := fnv.New64a()
+	h.Write([]byte(date + "/" + d.Name))
+	r := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	// Start from a sorted bag, since the initialized bag's order varies
+	bag := append(TileBag{}, initializedTileBag...)
+	sort.Slice(bag, func(i, j int) bool { return bag[i] < bag[j] })
+	r.Shuffle(len(bag), func(i, j int) { bag[i], bag[j] = bag[j], bag[i] })
+
+	p := &Puzzle{
+		Date:       date,
+		Dictionary: d.Name,
+		Board:      initializedBoard,
+	}
+	rack := &Player{}
+
+	turns := minPuzzleTurns + r.Intn(maxPuzzleTurns-minPuzzleTurns+1)
+	for turn := 0; turn <= turns; turn++ {
+		dealCount := maxTiles - len(rack.Tiles)
+		if dealCount > len(bag) {
+			dealCount = len(bag)
+		}
+		dealTiles(rack, &bag, dealCount)
+
+		moves := p.Board.generateMoves(rack.Tiles, d)
+		if turn == turns {
+			p.Rack = rack.Tiles
+			p.moves = moves
+			break
+		}
+
+		if len(moves) == 0 {
+			// Change the whole rack and try again
+			bag = append(bag, rack.Tiles...)
+			r.Shuffle(len(bag), func(i, j int) { bag[i], bag[j] = bag[j], bag[i] })
+			rack.Tiles = nil
+			continue
+		}
+
+		choices := puzzleChoices
+		if choices > len(moves) {
+			choices = len(moves)
+		}
+		m := moves[r.Intn(choices)]
+
+		placed, err := p.Board.evaluatePlay(m.request())
+		if err != nil {
+			return nil, err
+		}
+		p.Board.place(placed)
+		if err := removeTiles(rack, m.Tiles); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(p.moves) == 0 {
+		return nil, errors.New("No plays available for the puzzle's rack")
+	}
+
+	return p, nil
+}
+
+// grade scores an answer against the puzzle's moves. Credit is the answer's
+// score as a fraction of the top move's, so near-best plays earn most of it.
+func (p *Puzzle) grade(answer GamePlayRequest) (PuzzleGrade, error) {
+	var g PuzzleGrade
+
+	answer, err := p.Board.resolveMove(answer)
+	if err != nil {
+		return g, err
+	}
+
+	if err := removeTiles(&Player{Tiles: p.Rack}, answer.Tiles); err != nil {
+		return g, err
+	}
+
+	placed, err := p.Board.evaluatePlay(answer)
+	if err != nil {
+		return g, err
+	}
+
+	d, ok := getDictionary(p.Dictionary)
+	if !ok {
+		return g, unknownDictionary(p.Dictionary)
+	} else if !d.containsAll(placed.words) {
+		return g, newError(CodeInvalidWord, "Answer forms words not in "+d.Name)
+	}
+
+	g.Score = placed.score
+	g.Best = p.moves[0]
+	g.BestScore = g.Best.Score
+	g.Rank = 1
+	for _, m := range p.moves {
+		if m.Score > g.Score {
+			g.Rank++
+		}
+	}
+	if g.BestScore > 0 {
+		g.Credit = float64(g.Score) / float64(g.BestScore)
+	}
+
+	return g, nil
+}
+
+// puzzleHandler handles requests for the daily puzzle. It will respond using
+// the Puzzle struct, without the answers.
+func puzzleHandler(w http.ResponseWriter, r *http.Request) {
+	p, ok := puzzleRequestHelper(&PuzzleRequest{}, w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := json.Marshal(p)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// puzzleAnswerHandler handles requests to grade an answer to the daily puzzle.
+// It will respond using the PuzzleGrade struct.
+func puzzleAnswerHandler(w http.ResponseWriter, r *http.Request) {
+	var j PuzzleRequest
+
+	p, ok := puzzleRequestHelper(&j, w, r)
+	if !ok {
+		return
+	} else if j.Answer == nil {
+		writeError(w, newError(CodeBadRequest, "Answer is required"))
+		return
+	}
+
+	g, err := p.grade(*j.Answer)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := json.Marshal(g)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// puzzleRequestHelper decodes a puzzle request into j and looks up the puzzle
+// it asks for
+func puzzleRequestHelper(j *PuzzleRequest, w http.ResponseWriter, r *http.Request) (*Puzzle, bool) {
+	err := json.NewDecoder(r.Body).Decode(j)
+	if err != nil {
+		writeError(w, err)
+		return nil, false
+	}
+
+	if j.Date == "" {
+		j.Date = time.Now().Format("2006-01-02")
+	}
+
+	d, ok := getDictionary(j.Dictionary)
+	if !ok {
+		writeError(w, unknownDictionary(j.Dictionary))
+		return nil, false
+	}
+
+	p, err := dailyPuzzle(j.Date, d, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return nil, false
+	}
+
+	return p, true
+}
@@ -0,0 +1,130 @@
+package wordgameserver
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func loadTestWords(t *testing.T) *Dictionary {
+	d, err := LoadDictionary("testdata/words.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestGenerateMoves(t *testing.T) {
+	d := loadTestWords(t)
+	board := initializedBoard
+
+	moves := board.generateMoves([]byte("QUITZAX"), d)
+	if len(moves) == 0 {
+		t.Fatal("No moves found on empty board")
+	}
+
+	for i, m := range moves {
+		if i > 0 && m.Score > moves[i-1].Score {
+			t.Fatal("Moves are not sorted best first")
+		}
+		p, err := board.evaluatePlay(m.request())
+		if err != nil {
+			t.Fatalf("Generated move %+v is not playable: %v", m, err)
+		} else if p.score != m.Score || !d.containsAll(p.words) {
+			t.Fatalf("Generated move %+v does not match its evaluation", m)
+		}
+	}
+
+	// QUIZ is the best play from this rack on an empty board
+	if moves[0].Words[0] != "QUIZ" {
+		t.Errorf("Expected QUIZ as top move, got %v", moves[0].Words)
+	}
+}
+
+func TestDailyPuzzle(t *testing.T) {
+	d := loadTestWords(t)
+
+	p, err := generatePuzzle("2026-10-18", d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := generatePuzzle("2026-10-18", d)
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(p, again) {
+		t.Fatal("Puzzle is not the same for the same date")
+	}
+
+	other, err := generatePuzzle("2026-10-19", d)
+	if err != nil {
+		t.Fatal(err)
+	} else if reflect.DeepEqual(p.Board, other.Board) {
+		t.Error("Puzzles for different dates should differ")
+	}
+
+	// Top move earns full credit, and a worse move partial credit
+	g, err := p.grade(p.moves[0].request())
+	if err != nil {
+		t.Fatal(err)
+	} else if g.Credit != 1 || g.Rank != 1 {
+		t.Errorf("Top move graded %v credit at rank %v", g.Credit, g.Rank)
+	}
+
+	// Answers can be written in standard notation too
+	placed, err := p.Board.evaluatePlay(p.moves[0].request())
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := p.Board
+	board.place(placed)
+	g, err = p.grade(GamePlayRequest{Move: board.moveNotation(placed.squares)})
+	if err != nil {
+		t.Fatal(err)
+	} else if g.Credit != 1 || g.Rank != 1 {
+		t.Errorf("Top move in notation graded %v credit at rank %v", g.Credit, g.Rank)
+	}
+
+	worst := p.moves[len(p.moves)-1]
+	g, err = p.grade(worst.request())
+	if err != nil {
+		t.Fatal(err)
+	} else if worst.Score < p.moves[0].Score && (g.Credit >= 1 || g.Rank == 1) {
+		t.Errorf("Worse move graded %v credit at rank %v", g.Credit, g.Rank)
+	}
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	if _, err := dailyPuzzle("18/10/2026", d, now); err == nil {
+		t.Error("Badly formatted date should fail")
+	}
+}
+
+func TestDailyPuzzleDates(t *testing.T) {
+	d := loadTestWords(t)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	for _, date := range []string{"2026-10-17", "2026-10-18", "2026-10-19"} {
+		if _, err := dailyPuzzle(date, d, now); err != nil {
+			t.Errorf("Puzzle for %v should be available: %v", date, err)
+		}
+	}
+
+	for _, date := range []string{"2026-10-16", "2026-10-20", "1999-01-01"} {
+		if _, err := dailyPuzzle(date, d, now); err == nil {
+			t.Errorf("Puzzle for %v should not be available", date)
+		}
+	}
+
+	// A day later, the oldest puzzle has expired from the cache
+	if _, err := dailyPuzzle("2026-10-20", d, now.Add(24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	puzzlesMu.Lock()
+	defer puzzlesMu.Unlock()
+	for _, p := range puzzles {
+		if p.Date == "2026-10-17" {
+			t.Fatal("Puzzle outside the window is still cached")
+		}
+	}
+}
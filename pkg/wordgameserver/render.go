@@ -0,0 +1,349 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Layout of rendered boards, in pixels
+const (
+	renderSquare    = 36 // width and height of a square
+	renderGap       = 2  // space between squares
+	renderMargin    = 24 // space around the board for coordinates
+	renderScoreLine = 24 // height of each line of scores below the board
+)
+
+// Colors used when rendering boards
+var (
+	renderBackground = color.RGBA{0x1f, 0x4e, 0x3d, 0xff}
+	renderLabel      = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	renderTile       = color.RGBA{0xf6, 0xdc, 0x9c, 0xff}
+	renderLastTile   = color.RGBA{0xff, 0xb3, 0x47, 0xff}
+	renderTileText   = color.RGBA{0x30, 0x20, 0x10, 0xff}
+	renderBlankText  = color.RGBA{0xb0, 0x30, 0x30, 0xff}
+	renderSquareText = color.RGBA{0x40, 0x40, 0x40, 0xff}
+)
+
+// squareColors is the color of each type of square
+var squareColors = map[string]color.RGBA{
+	"plain":        {0xe8, 0xe0, 0xc8, 0xff},
+	"star":         {0xf4, 0xa6, 0xb8, 0xff},
+	"doubleLetter": {0xa8, 0xd8, 0xf0, 0xff},
+	"tripleLetter": {0x2f, 0x80, 0xd0, 0xff},
+	"doubleWord":   {0xf4, 0xa6, 0xb8, 0xff},
+	"tripleWord":   {0xd8, 0x40, 0x40, 0xff},
+}
+
+// squareLabels is the text shown on empty premium squares
+var squareLabels = map[string]string{
+	"star":         "*",
+	"doubleLetter": "DL",
+	"tripleLetter": "TL",
+	"doubleWord":   "DW",
+	"tripleWord":   "TW",
+}
+
+// renderOptions are the extras drawn along with a board
+type renderOptions struct {
+	lastPlay []SquareCoordinate // squares to highlight
+	players  []*Player          // players whose scores are listed, if any
+}
+
+// hexColor writes a color in the form used by SVG
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// renderSize returns the width and height of a rendered board
+func renderSize(o renderOptions) (int, int) {
+	size := 2*renderMargin + columnCount*renderSquare
+	return size, size + len(o.players)*renderScoreLine
+}
+
+// squareOrigin returns the top left corner of a square in a rendered board
+func squareOrigin(sc SquareCoordinate) (int, int) {
+	return renderMargin + sc.Col*renderSquare + renderGap/2, renderMargin + sc.Row*renderSquare + renderGap/2
+}
+
+// highlighted reports whether the square is part of the last play
+func (o renderOptions) highlighted(sc SquareCoordinate) bool {
+	for _, l := range o.lastPlay {
+		if l == sc {
+			return true
+		}
+	}
+	return false
+}
+
+// renderSVG draws the board as an SVG image
+func renderSVG(w io.Writer, sb *ScrabbleBoard, o renderOptions) error {
+	var b bytes.Buffer
+	width, height := renderSize(o)
+	inner := renderSquare - renderGap
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`+"\n", width, height, hexColor(renderBackground))
+
+	// Column letters along the top and row numbers down the side
+	for i := 0; i < columnCount; i++ {
+		x, _ := squareOrigin(SquareCoordinate{Col: i})
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="14" text-anchor="middle" fill="%s">%c</text>`+"\n", x+inner/2, renderMargin-7, hexColor(renderLabel), 'A'+i)
+	}
+	for i := 0; i < rowCount; i++ {
+		_, y := squareOrigin(SquareCoordinate{Row: i})
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="14" text-anchor="middle" fill="%s">%d</text>`+"\n", renderMargin/2, y+inner/2+5, hexColor(renderLabel), i+1)
+	}
+
+	for r, row := range sb {
+		for c, square := range row {
+			sc := SquareCoordinate{Row: r, Col: c}
+			x, y := squareOrigin(sc)
+
+			if square.Letter == 0 {
+				fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, y, inner, inner, hexColor(squareColors[square.SquareType]))
+				if label, ok := squareLabels[square.SquareType]; ok {
+					fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="11" text-anchor="middle" fill="%s">%s</text>`+"\n", x+inner/2, y+inner/2+4, hexColor(renderSquareText), label)
+				}
+				continue
+			}
+
+			fill := renderTile
+			if o.highlighted(sc) {
+				fill = renderLastTile
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="3" fill="%s"/>`+"\n", x, y, inner, inner, hexColor(fill))
+
+			text := renderTileText
+			if square.Value == 0 {
+				text = renderBlankText
+			}
+			fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="20" font-weight="bold" text-anchor="middle" fill="%s">%c</text>`+"\n", x+inner/2-2, y+inner/2+7, hexColor(text), square.Letter)
+			if square.Value != 0 {
+				fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="9" text-anchor="end" fill="%s">%d</text>`+"\n", x+inner-2, y+inner-3, hexColor(renderTileText), square.Value)
+			}
+		}
+	}
+
+	for i, p := range o.players {
+		y := 2*renderMargin + rowCount*renderSquare + i*renderScoreLine
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="16" fill="%s">%s: %d</text>`+"\n", renderMargin, y, hexColor(renderLabel), html.EscapeString(p.Name), p.Score)
+	}
+
+	fmt.Fprintln(&b, "</svg>")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// renderPNG draws the board as a PNG image
+func renderPNG(w io.Writer, sb *ScrabbleBoard, o renderOptions) error {
+	width, height := renderSize(o)
+	inner := renderSquare - renderGap
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{renderBackground}, image.Point{}, draw.Src)
+
+	fill := func(x, y int, c color.RGBA) {
+		draw.Draw(img, image.Rect(x, y, x+inner, y+inner), &image.Uniform{c}, image.Point{}, draw.Src)
+	}
+
+	// Column letters along the top and row numbers down the side
+	for i := 0; i < columnCount; i++ {
+		x, _ := squareOrigin(SquareCoordinate{Col: i})
+		label := string(rune('A' + i))
+		drawText(img, label, x+(inner-textWidth(label, 2))/2, (renderMargin-glyphHeight*2)/2, 2, renderLabel)
+	}
+	for i := 0; i < rowCount; i++ {
+		_, y := squareOrigin(SquareCoordinate{Row: i})
+		label := strconv.Itoa(i + 1)
+		drawText(img, label, (renderMargin-textWidth(label, 1))/2, y+(inner-glyphHeight)/2, 1, renderLabel)
+	}
+
+	for r, row := range sb {
+		for c, square := range row {
+			sc := SquareCoordinate{Row: r, Col: c}
+			x, y := squareOrigin(sc)
+
+			if square.Letter == 0 {
+				fill(x, y, squareColors[square.SquareType])
+				if label, ok := squareLabels[square.SquareType]; ok {
+					drawText(img, label, x+(inner-textWidth(label, 1))/2, y+(inner-glyphHeight)/2, 1, renderSquareText)
+				}
+				continue
+			}
+
+			if o.highlighted(sc) {
+				fill(x, y, renderLastTile)
+			} else {
+				fill(x, y, renderTile)
+			}
+
+			text := renderTileText
+			if square.Value == 0 {
+				text = renderBlankText
+			}
+			drawText(img, string(square.Letter), x+(inner-textWidth("W", 3))/2-2, y+(inner-glyphHeight*3)/2, 3, text)
+			if square.Value != 0 {
+				value := strconv.Itoa(square.Value)
+				drawText(img, value, x+inner-textWidth(value, 1)-2, y+inner-glyphHeight-2, 1, renderTileText)
+			}
+		}
+	}
+
+	for i, p := range o.players {
+		y := renderMargin + rowCount*renderSquare + renderMargin/2 + i*renderScoreLine
+		drawText(img, p.Name+": "+strconv.Itoa(p.Score), renderMargin, y, 2, renderLabel)
+	}
+
+	return png.Encode(w, img)
+}
+
+// lastPlaySquares replays the plays in the history to find the squares the
+// most recent play still on the board was placed on
+func lastPlaySquares(events []GameEvent) []SquareCoordinate {
+	board := initializedBoard
+	var plays [][]SquareCoordinate
+	for _, e := range events {
+		switch e.Type {
+		case "play":
+			squares, err := board.replayPlay(e)
+			if err != nil {
+				return nil
+			}
+			plays = append(plays, squares)
+		case "withdrawn":
+			if len(plays) == 0 {
+				continue
+			}
+			for _, sc := range plays[len(plays)-1] {
+				board[sc.Row][sc.Col].Tile = Tile{}
+			}
+			plays = plays[:len(plays)-1]
+		}
+	}
+
+	if len(plays) == 0 {
+		return nil
+	}
+	return plays[len(plays)-1]
+}
+
+// boardView returns the board and players as last published to spectators,
+// or as they are if the game has not started, so boards can be drawn without
+// going through the state controller
+func (sg *ScrabbleGame) boardView() (ScrabbleBoard, []*Player) {
+	sg.spectators.Lock()
+	last := sg.spectators.last
+	sg.spectators.Unlock()
+	if last != nil {
+		return last.Board, last.Players
+	}
+
+	sg.Lock()
+	defer sg.Unlock()
+	if sg.Active {
+		// Nothing has been published yet
+		return initializedBoard, nil
+	}
+	return sg.Board, snapshotPlayers(sg.playerList())
+}
+
+// queryBool reads a true or false query parameter, which is false if not set
+func queryBool(q url.Values, name string) (bool, error) {
+	s := q.Get(name)
+	if s == "" {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errors.New("Invalid value for " + name)
+	}
+	return v, nil
+}
+
+// boardRenderHelper finds the game to draw from the game_id query parameter
+// and reads the options for drawing it. The last play is highlighted if
+// last_play is true, and scores are listed if scores is true.
+func boardRenderHelper(w http.ResponseWriter, r *http.Request) (ScrabbleBoard, renderOptions, bool) {
+	var o renderOptions
+	q := r.URL.Query()
+
+	gameID, err := uuid.Parse(q.Get("game_id"))
+	if err != nil {
+		writeError(w, err)
+		return ScrabbleBoard{}, o, false
+	}
+
+	lastPlay, err := queryBool(q, "last_play")
+	if err != nil {
+		writeError(w, err)
+		return ScrabbleBoard{}, o, false
+	}
+	scores, err := queryBool(q, "scores")
+	if err != nil {
+		writeError(w, err)
+		return ScrabbleBoard{}, o, false
+	}
+
+	g, err := getGame(gameID, w)
+	if err != nil {
+		return ScrabbleBoard{}, o, false
+	}
+
+	board, players := g.boardView()
+	if lastPlay {
+		o.lastPlay = lastPlaySquares(g.history.list())
+	}
+	if scores {
+		o.players = players
+	}
+
+	return board, o, true
+}
+
+// boardSVGHandler handles requests for an SVG image of a game's board
+func boardSVGHandler(w http.ResponseWriter, r *http.Request) {
+	board, o, ok := boardRenderHelper(w, r)
+	if !ok {
+		return
+	}
+
+	var b bytes.Buffer
+	if err := renderSVG(&b, &board, o); err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b.Bytes())
+}
+
+// boardPNGHandler handles requests for a PNG image of a game's board
+func boardPNGHandler(w http.ResponseWriter, r *http.Request) {
+	board, o, ok := boardRenderHelper(w, r)
+	if !ok {
+		return
+	}
+
+	var b bytes.Buffer
+	if err := renderPNG(&b, &board, o); err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b.Bytes())
+}
@@ -0,0 +1,104 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRenderSVG(t *testing.T) {
+	board := initializedBoard
+	board[7][7].Tile = tiles['Q']
+	board[7][8].Tile = Tile{Letter: 'I'}
+
+	var b bytes.Buffer
+	err := renderSVG(&b, &board, renderOptions{
+		lastPlay: []SquareCoordinate{{Row: 7, Col: 7}},
+		players:  []*Player{{Name: "A & B", Score: 11}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svg := b.String()
+	for _, want := range []string{
+		"<svg ", ">Q</text>", ">10</text>", ">I</text>", ">TW</text>",
+		hexColor(renderLastTile), hexColor(renderBlankText), ">A &amp; B: 11</text>",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG does not contain %q", want)
+		}
+	}
+}
+
+func TestRenderPNG(t *testing.T) {
+	board := initializedBoard
+	board[0][0].Tile = tiles['A']
+
+	var b bytes.Buffer
+	err := renderPNG(&b, &board, renderOptions{
+		lastPlay: []SquareCoordinate{{Row: 0, Col: 0}},
+		players:  []*Player{{Name: "Ann"}, {Name: "Ben"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	width, height := renderSize(renderOptions{players: make([]*Player, 2)})
+	if size := img.Bounds().Size(); size.X != width || size.Y != height {
+		t.Fatalf("Image is %v, expected %vx%v", size, width, height)
+	}
+
+	// Corners of squares are clear of any text
+	x, y := squareOrigin(SquareCoordinate{Row: 0, Col: 0})
+	if r, g, bl, _ := img.At(x, y).RGBA(); uint8(r>>8) != renderLastTile.R || uint8(g>>8) != renderLastTile.G || uint8(bl>>8) != renderLastTile.B {
+		t.Error("Last play was not highlighted")
+	}
+	x, y = squareOrigin(SquareCoordinate{Row: 0, Col: 7})
+	if r, _, _, _ := img.At(x, y).RGBA(); uint8(r>>8) != squareColors["tripleWord"].R {
+		t.Error("Premium square was not drawn in its color")
+	}
+}
+
+func TestBoardImageHandlers(t *testing.T) {
+	rr := httptest.NewRecorder()
+	importGCGHandler(rr, httptest.NewRequest("POST", "/game/import", strings.NewReader(testGCG)))
+
+	var j GeneralGameRequest
+	if err := json.NewDecoder(rr.Body).Decode(&j); err != nil {
+		t.Fatal(err)
+	}
+
+	rr = httptest.NewRecorder()
+	boardSVGHandler(rr, httptest.NewRequest("GET", "/game/board.svg?last_play=true&scores=1&game_id="+j.GameID.String(), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", rr.Code, http.StatusOK)
+	} else if ct := rr.Header().Get("Content-Type"); ct != "image/svg+xml" {
+		t.Errorf("Content type is %v", ct)
+	} else if svg := rr.Body.String(); !strings.Contains(svg, "Alice Smith: 44") || strings.Count(svg, hexColor(renderLastTile)) != 1 {
+		t.Error("SVG is missing the scores or the highlighted last play")
+	}
+
+	rr = httptest.NewRecorder()
+	boardPNGHandler(rr, httptest.NewRequest("GET", "/game/board.png?game_id="+j.GameID.String(), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", rr.Code, http.StatusOK)
+	} else if _, err := png.Decode(rr.Body); err != nil {
+		t.Error(err)
+	}
+
+	rr = httptest.NewRecorder()
+	boardSVGHandler(rr, httptest.NewRequest("GET", "/game/board.svg?scores=maybe&game_id="+j.GameID.String(), nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Returned status code %v, expected %v", rr.Code, http.StatusBadRequest)
+	}
+}
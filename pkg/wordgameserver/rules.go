@@ -0,0 +1,39 @@
+package wordgameserver
+
+// GameRules holds the configurable options of a game instance. The zero value
+// is a casual game with every feature enabled.
+type GameRules struct {
+	Dictionary          string `json:"dictionary,omitempty"`            // name of the word list plays are checked against, none if not set
+	Clabbers            bool   `json:"clabbers,omitempty"`              // accept any anagram of a dictionary word
+	TurnOrder           string `json:"turn_order,omitempty"`            // join, random or draw, join order if not set
+	DisableTileTracking bool   `json:"disable_tile_tracking,omitempty"` // hide unseen tiles, as in strict tournament play
+	ClockMinutes        int    `json:"clock_minutes,omitempty"`         // time each player has for the whole game, zero for untimed
+	ForfeitOnTime       bool   `json:"forfeit_on_time,omitempty"`       // forfeit players who run out of time instead of penalizing them
+	TurnDeadlineHours   int    `json:"turn_deadline_hours,omitempty"`   // hours each turn may take in correspondence games, zero for none
+	ForfeitOnDeadline   bool   `json:"forfeit_on_deadline,omitempty"`   // forfeit players who miss a deadline instead of passing their turn
+
+	SpectatorRackDelaySeconds int `json:"spectator_rack_delay_seconds,omitempty"` // delay before spectators see racks, zero to never show them
+
+	Duplicate             bool `json:"duplicate,omitempty"`               // every player plays the same rack each round and scores their own move
+	DuplicateRoundSeconds int  `json:"duplicate_round_seconds,omitempty"` // time to submit a move each duplicate round, zero for no limit
+
+	Speed bool `json:"speed,omitempty"` // every player builds a private grid at once, peeling from a shared bag
+
+	Teams           bool `json:"teams,omitempty"`             // two teams of two sharing a score
+	TeamRackSharing bool `json:"team_rack_sharing,omitempty"` // let teammates see each other's racks
+
+	SpectatorChat bool     `json:"spectator_chat,omitempty"` // let spectators read and post to the chat
+	ChatFilter    []string `json:"chat_filter,omitempty"`    // words masked out of chat messages
+}
+
+// validate checks that the rules can be used together
+func (r GameRules) validate() error {
+	if err := r.validateDuplicate(); err != nil {
+		return err
+	} else if err := r.validateSpeed(); err != nil {
+		return err
+	} else if err := r.validateDictionary(); err != nil {
+		return err
+	}
+	return r.validateTurnOrder()
+}
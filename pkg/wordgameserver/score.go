@@ -0,0 +1,201 @@
+package wordgameserver
+
+import (
+	"errors"
+	"strings"
+)
+
+// bingoBonus is the number of points added for playing every tile in a rack
+const bingoBonus = 50
+
+// starSquare is the square the first play must cover
+var starSquare = SquareCoordinate{Row: rowCount / 2, Col: columnCount / 2}
+
+// placement is a validated play, ready to be put on the board
+type placement struct {
+	squares []SquareCoordinate // empty squares the tiles are placed on
+	tiles   []Tile             // tiles placed, with blanks given their letter
+	words   []string           // words formed, main word first, blanks lowercase
+	score   int                // total score of the play
+}
+
+// onBoard reports whether the coordinate is on the board
+func (sc SquareCoordinate) onBoard() bool {
+	return sc.Row >= 0 && sc.Row < rowCount && sc.Col >= 0 && sc.Col < columnCount
+}
+
+// occupied reports whether a tile has been placed on the square
+func (sb *ScrabbleBoard) occupied(sc SquareCoordinate) bool {
+	return sc.onBoard() && sb[sc.Row][sc.Col].Letter != 0
+}
+
+// evaluatePlay checks that the tiles can be placed in a line from the start
+// position to the end position, filling the empty squares in order, and
+// scores the words formed. Blanks are played as spaces and given letters from
+// Blanks in order. The board is not changed.
+func (sb *ScrabbleBoard) evaluatePlay(j GamePlayRequest) (placement, error) {
+	var p placement
+
+	if len(j.Tiles) == 0 {
+		return p, newError(CodeInvalidPlacement, "No tiles played")
+	} else if !j.StartPos.onBoard() || !j.EndPos.onBoard() {
+		return p, newError(CodeInvalidPlacement, "Play is off the board")
+	}
+
+	// Work out the direction of the play. A single tile is read across.
+	dr, dc := 0, 1
+	switch {
+	case j.StartPos.Row == j.EndPos.Row && j.StartPos.Col <= j.EndPos.Col:
+	case j.StartPos.Col == j.EndPos.Col && j.StartPos.Row < j.EndPos.Row:
+		dr, dc = 1, 0
+	default:
+		return p, newError(CodeInvalidPlacement, "Tiles must be played in a line, left to right or top to bottom")
+	}
+
+	// Give each tile its letter and value
+	blanks := j.Blanks
+	for _, t := range j.Tiles {
+		tile, ok := tiles[t]
+		if !ok {
+			return p, errors.New("Unknown tile '" + string(t) + "'")
+		}
+		if t == ' ' {
+			if len(blanks) == 0 {
+				return p, errors.New("No letter given for blank")
+			}
+			tile.Letter, blanks = blanks[0], blanks[1:]
+			if _, ok := tiles[tile.Letter]; !ok || tile.Letter == ' ' {
+				return p, errors.New("Blank must be given a letter from A to Z")
+			}
+		}
+		tile.Count = 0
+		p.tiles = append(p.tiles, tile)
+	}
+	if len(blanks) != 0 {
+		return p, errors.New("More blank letters given than blanks played")
+	}
+
+	// Fill the empty squares between the start and end positions
+	firstPlay := !sb.occupied(starSquare)
+	touches := false
+	for sc := j.StartPos; ; sc = (SquareCoordinate{Row: sc.Row + dr, Col: sc.Col + dc}) {
+		if sb.occupied(sc) {
+			touches = true
+		} else {
+			p.squares = append(p.squares, sc)
+		}
+		if sc == j.EndPos {
+			break
+		}
+	}
+	if len(p.squares) != len(p.tiles) {
+		return p, newError(CodeInvalidPlacement, "Number of tiles does not match the empty squares played on")
+	}
+
+	// Lay the tiles on a copy of the board to read the words formed
+	board := *sb
+	for i, sc := range p.squares {
+		board[sc.Row][sc.Col].Tile = p.tiles[i]
+		if sc == starSquare {
+			touches = true
+		}
+		for _, n := range []SquareCoordinate{
+			{Row: sc.Row - 1, Col: sc.Col}, {Row: sc.Row + 1, Col: sc.Col},
+			{Row: sc.Row, Col: sc.Col - 1}, {Row: sc.Row, Col: sc.Col + 1},
+		} {
+			if sb.occupied(n) {
+				touches = true
+			}
+		}
+	}
+
+	if firstPlay && board[starSquare.Row][starSquare.Col].Letter == 0 {
+		return p, newError(CodeInvalidPlacement, "First play must cover the center square")
+	} else if firstPlay && len(p.tiles) < 2 {
+		return p, newError(CodeInvalidPlacement, "First play must be at least two tiles")
+	} else if !touches {
+		return p, newError(CodeInvalidPlacement, "Play must connect to tiles on the board")
+	}
+
+	placed := make(map[SquareCoordinate]bool, len(p.squares))
+	for _, sc := range p.squares {
+		placed[sc] = true
+	}
+
+	// Score the main word, then the words formed across it
+	if w, s, ok := board.wordAt(p.squares[0], dr, dc, placed); ok {
+		p.words = append(p.words, w)
+		p.score += s
+	}
+	for _, sc := range p.squares {
+		if w, s, ok := board.wordAt(sc, dc, dr, placed); ok {
+			p.words = append(p.words, w)
+			p.score += s
+		}
+	}
+
+	if len(p.words) == 0 {
+		return p, newError(CodeInvalidPlacement, "Play does not form a word")
+	}
+
+	if len(p.tiles) == maxTiles {
+		p.score += bingoBonus
+	}
+
+	return p, nil
+}
+
+// wordAt reads the word running through the square in the given direction
+// and scores it. Premium squares only count for newly placed tiles. It
+// returns false if no word of at least two letters runs through the square.
+func (sb *ScrabbleBoard) wordAt(sc SquareCoordinate, dr, dc int, placed map[SquareCoordinate]bool) (string, int, bool) {
+	// Walk back to the start of the word
+	for prev := (SquareCoordinate{Row: sc.Row - dr, Col: sc.Col - dc}); sb.occupied(prev); prev = (SquareCoordinate{Row: prev.Row - dr, Col: prev.Col - dc}) {
+		sc = prev
+	}
+
+	var word strings.Builder
+	score, multiplier := 0, 1
+	for ; sb.occupied(sc); sc = (SquareCoordinate{Row: sc.Row + dr, Col: sc.Col + dc}) {
+		square := sb[sc.Row][sc.Col]
+		letterValue := square.Value
+		if placed[sc] {
+			st := squareTypes[square.SquareType]
+			letterValue *= st.LetterMultiplier
+			multiplier *= st.WordMultiplier
+		}
+		score += letterValue
+
+		letter := square.Letter
+		if square.Value == 0 {
+			letter += 'a' - 'A'
+		}
+		word.WriteByte(letter)
+	}
+
+	if word.Len() < 2 {
+		return "", 0, false
+	}
+
+	return word.String(), score * multiplier, true
+}
+
+// played spells out the tiles of a play in the order they are placed, with
+// blanks in lowercase
+func (p placement) played() string {
+	letters := make([]byte, len(p.tiles))
+	for i, t := range p.tiles {
+		letters[i] = t.Letter
+		if t.Value == 0 {
+			letters[i] += 'a' - 'A'
+		}
+	}
+	return string(letters)
+}
+
+// place puts the tiles of a validated play on the board
+func (sb *ScrabbleBoard) place(p placement) {
+	for i, sc := range p.squares {
+		sb[sc.Row][sc.Col].Tile = p.tiles[i]
+	}
+}
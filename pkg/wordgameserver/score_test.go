@@ -0,0 +1,129 @@
+package wordgameserver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvaluatePlay(t *testing.T) {
+	board := initializedBoard
+
+	tests := []struct {
+		play  GamePlayRequest
+		words []string
+		score int
+		err   bool
+	}{
+		{ // First play must cover the center square
+			play: GamePlayRequest{StartPos: SquareCoordinate{Row: 7, Col: 2}, EndPos: SquareCoordinate{Row: 7, Col: 4}, Tiles: []byte("CAT")},
+			err:  true,
+		},
+		{
+			play:  GamePlayRequest{StartPos: SquareCoordinate{Row: 7, Col: 6}, EndPos: SquareCoordinate{Row: 7, Col: 8}, Tiles: []byte("CAT")},
+			words: []string{"CAT"},
+			score: 5,
+		},
+		{ // Plays must connect to tiles on the board
+			play: GamePlayRequest{StartPos: SquareCoordinate{Row: 0, Col: 0}, EndPos: SquareCoordinate{Row: 0, Col: 1}, Tiles: []byte("AT")},
+			err:  true,
+		},
+		{ // Playing through the A of CAT
+			play:  GamePlayRequest{StartPos: SquareCoordinate{Row: 6, Col: 7}, EndPos: SquareCoordinate{Row: 7, Col: 7}, Tiles: []byte("Z")},
+			words: []string{"ZA"},
+			score: 11,
+		},
+		{ // Double letter counted in both words
+			play:  GamePlayRequest{StartPos: SquareCoordinate{Row: 6, Col: 8}, EndPos: SquareCoordinate{Row: 6, Col: 8}, Tiles: []byte("A")},
+			words: []string{"ZA", "AT"},
+			score: 15,
+		},
+		{ // Blanks score nothing and are shown in lowercase
+			play:  GamePlayRequest{StartPos: SquareCoordinate{Row: 7, Col: 9}, EndPos: SquareCoordinate{Row: 7, Col: 9}, Tiles: []byte(" "), Blanks: []byte("S")},
+			words: []string{"CATs"},
+			score: 5,
+		},
+		{ // Tiles must fill the empty squares played on
+			play: GamePlayRequest{StartPos: SquareCoordinate{Row: 8, Col: 6}, EndPos: SquareCoordinate{Row: 8, Col: 9}, Tiles: []byte("AT")},
+			err:  true,
+		},
+	}
+
+	for i, test := range tests {
+		p, err := board.evaluatePlay(test.play)
+		if test.err {
+			if err == nil {
+				t.Errorf("Play %v should have failed", i)
+			}
+			continue
+		} else if err != nil {
+			t.Fatalf("Play %v failed: %v", i, err)
+		}
+
+		if !reflect.DeepEqual(p.words, test.words) {
+			t.Errorf("Play %v formed %v, expected %v", i, p.words, test.words)
+		} else if p.score != test.score {
+			t.Errorf("Play %v scored %v, expected %v", i, p.score, test.score)
+		}
+
+		board.place(p)
+	}
+}
+
+func TestBingoBonus(t *testing.T) {
+	board := initializedBoard
+
+	p, err := board.evaluatePlay(GamePlayRequest{
+		StartPos: SquareCoordinate{Row: 7, Col: 1},
+		EndPos:   SquareCoordinate{Row: 7, Col: 7},
+		Tiles:    []byte("RETAINS"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// T lands on a double letter, the rest on plain squares
+	if expected := 8 + bingoBonus; p.score != expected {
+		t.Errorf("Bingo scored %v, expected %v", p.score, expected)
+	}
+}
+
+func TestPlayTiles(t *testing.T) {
+	newGame := createScrabbleGame()
+	playerID, _ := newGame.addPlayer("ashley1")
+	newGame.addPlayer("ashley2")
+
+	p := newGame.Players[playerID]
+	p.Tiles = []byte("CATXYZQ")
+
+	err := newGame.executePlay(GamePlayRequest{
+		PlayerID: playerID,
+		StartPos: SquareCoordinate{Row: 7, Col: 6},
+		EndPos:   SquareCoordinate{Row: 7, Col: 8},
+		Tiles:    []byte("CAT"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Score != 5 {
+		t.Errorf("Expected score of 5, got %v", p.Score)
+	} else if len(p.Tiles) != maxTiles || string(p.Tiles[:4]) != "XYZQ" {
+		t.Errorf("Player was not dealt new tiles: %v", string(p.Tiles))
+	} else if newGame.Board[7][7].Letter != 'A' {
+		t.Error("Tiles were not placed on the board")
+	}
+
+	// Tiles not in the rack leave the rack untouched
+	newGame.TurnCount = 0
+	err = newGame.executePlay(GamePlayRequest{
+		PlayerID: playerID,
+		StartPos: SquareCoordinate{Row: 8, Col: 6},
+		EndPos:   SquareCoordinate{Row: 8, Col: 7},
+		Tiles:    []byte("X!"),
+	})
+	if err == nil {
+		t.Fatal("Play with unknown tile should fail")
+	} else if len(p.Tiles) != maxTiles {
+		t.Fatal("Failed play changed the player's rack")
+	}
+}
@@ -0,0 +1,207 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// spectatorBuffer is the number of updates a spectator can fall behind before
+// updates are dropped for them
+const spectatorBuffer = 16
+
+// SpectatorUpdate is the game state sent to spectators. Racks are only
+// included in delayed updates, and only if the game's rules allow it.
+type SpectatorUpdate struct {
+	GameID         uuid.UUID      `json:"game_id"`
+	Players        []*Player      `json:"players"`
+	Board          ScrabbleBoard  `json:"board"`
+	PlayerTurn     int            `json:"turn"`
+	TurnCount      int            `json:"turn_count"`
+	TilesRemaining int            `json:"tiles_remaining"`
+	Teams          []TeamScore    `json:"teams,omitempty"`
+	Winner         string         `json:"winner,omitempty"` // set once the game is over
+	Racks          map[int][]byte `json:"racks,omitempty"`  // racks indexed by player number, as they were at TurnCount
+}
+
+// spectatorEvent is a single server-sent event delivered to subscribers
+type spectatorEvent struct {
+	Name string      // event name, such as state or chat
+	Data interface{} // payload encoded as JSON
+}
+
+// spectators fans game updates out to any number of subscribers without
+// blocking the state controller. Players may subscribe too, in which case
+// their player ID is recorded.
+type spectators struct {
+	sync.Mutex
+	subscribers map[chan spectatorEvent]*uuid.UUID
+	last        *SpectatorUpdate // most recent update without racks
+}
+
+// newSpectators creates an empty set of spectators
+func newSpectators() *spectators {
+	return &spectators{
+		subscribers: make(map[chan spectatorEvent]*uuid.UUID),
+	}
+}
+
+// subscribe adds a subscriber and sends them the latest update, if any. The
+// player ID is nil for spectators.
+func (s *spectators) subscribe(playerID *uuid.UUID) chan spectatorEvent {
+	ch := make(chan spectatorEvent, spectatorBuffer)
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.subscribers[ch] = playerID
+	if s.last != nil {
+		ch <- spectatorEvent{Name: "state", Data: *s.last}
+	}
+
+	return ch
+}
+
+// unsubscribe removes a subscriber
+func (s *spectators) unsubscribe(ch chan spectatorEvent) {
+	s.Lock()
+	delete(s.subscribers, ch)
+	s.Unlock()
+}
+
+// latest returns the most recent update without racks, or nil if there has
+// been none
+func (s *spectators) latest() *SpectatorUpdate {
+	s.Lock()
+	defer s.Unlock()
+	return s.last
+}
+
+// publish sends an update to every subscriber. Updates with racks only go to
+// spectators, since players subscribed to the game must not see other racks.
+func (s *spectators) publish(u SpectatorUpdate) {
+	s.Lock()
+	defer s.Unlock()
+
+	if u.Racks == nil {
+		s.last = &u
+		s.send(spectatorEvent{Name: "state", Data: u}, nil)
+		return
+	}
+
+	s.send(spectatorEvent{Name: "state", Data: u}, func(playerID *uuid.UUID) bool {
+		return playerID == nil
+	})
+}
+
+// send delivers an event to the subscribers accepted by the filter, or to all
+// of them if the filter is nil. Subscribers who are too far behind miss the
+// event rather than holding up the game. The lock must be held.
+func (s *spectators) send(e spectatorEvent, accept func(playerID *uuid.UUID) bool) {
+	for ch, playerID := range s.subscribers {
+		if accept != nil && !accept(playerID) {
+			continue
+		}
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// broadcast publishes the current game state to spectators, and schedules the
+// racks to be revealed once the rules' delay has passed
+func (sg *ScrabbleGame) broadcast(playerList []*Player) {
+	u := SpectatorUpdate{
+		GameID:         sg.ID,
+		Players:        snapshotPlayers(playerList),
+		Board:          sg.Board,
+		PlayerTurn:     sg.TurnCount % len(playerList),
+		TurnCount:      sg.TurnCount,
+		TilesRemaining: len(sg.TileBag),
+		Teams:          teamScores(playerList),
+		Winner:         sg.Winner,
+	}
+	sg.spectators.publish(u)
+
+	if sg.Rules.SpectatorRackDelaySeconds <= 0 {
+		return
+	}
+
+	u.Racks = make(map[int][]byte, len(playerList))
+	for _, p := range playerList {
+		rack := make([]byte, len(p.Tiles))
+		copy(rack, p.Tiles)
+		u.Racks[p.Number] = rack
+	}
+
+	delay := time.Duration(sg.Rules.SpectatorRackDelaySeconds) * time.Second
+	time.AfterFunc(delay, func() {
+		sg.spectators.publish(u)
+	})
+}
+
+// watchGameHandler streams game updates and chat to spectators as server-sent
+// events. The game is chosen with the game_id query parameter so browsers can
+// connect with EventSource. Players may pass their player_id to receive the
+// chat as players.
+func watchGameHandler(w http.ResponseWriter, r *http.Request) {
+	var playerID *uuid.UUID
+
+	gameID, err := uuid.Parse(r.URL.Query().Get("game_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if p := r.URL.Query().Get("player_id"); p != "" {
+		id, err := uuid.Parse(p)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		playerID = &id
+	}
+
+	g, err := getGame(gameID, w)
+	if err != nil {
+		return
+	}
+
+	if playerID != nil && !g.hasPlayer(*playerID) {
+		writeError(w, errPlayerNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, newError(CodeStreamUnsupported, "Streaming not supported"))
+		return
+	}
+
+	updates := g.spectators.subscribe(playerID)
+	defer g.spectators.unsubscribe(updates)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-updates:
+			data, err := json.Marshal(e.Data)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Name, data)
+			flusher.Flush()
+		}
+	}
+}
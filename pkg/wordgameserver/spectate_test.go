@@ -0,0 +1,129 @@
+package wordgameserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWatchGameHandler(t *testing.T) {
+	newGame := createScrabbleGame()
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(watchGameHandler))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "?game_id=" + newGame.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if c := resp.StatusCode; c != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", c, http.StatusOK)
+	}
+
+	newGame.start()
+
+	events := bufio.NewScanner(resp.Body)
+	u := nextSpectatorUpdate(t, events)
+	if u.GameID != newGame.ID || u.PlayerTurn != 0 {
+		t.Fatalf("Unexpected initial update: %+v", u)
+	} else if u.Racks != nil {
+		t.Fatal("Spectators should not see racks")
+	}
+
+	// Spectators are updated when a turn is played
+	s, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = newGame.request(GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: playerIDs[0],
+		Tiles:    s.PlayerTiles[:2],
+		Swap:     true,
+		Play:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u = nextSpectatorUpdate(t, events)
+	if u.PlayerTurn != 1 {
+		t.Fatalf("Expected update for second player's turn, got turn %v", u.PlayerTurn)
+	}
+}
+
+func TestSpectatorRackDelay(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.SpectatorRackDelaySeconds = 1
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	updates := newGame.spectators.subscribe(nil)
+	defer newGame.spectators.unsubscribe(updates)
+
+	// Players watching the game are sent the same updates, without racks
+	playerUpdates := newGame.spectators.subscribe(&playerIDs[0])
+	defer newGame.spectators.unsubscribe(playerUpdates)
+
+	started := time.Now()
+	newGame.start()
+
+	if e := <-updates; e.Data.(SpectatorUpdate).Racks != nil {
+		t.Fatal("Racks should not be shown immediately")
+	}
+
+	select {
+	case e := <-updates:
+		u := e.Data.(SpectatorUpdate)
+		if time.Since(started) < time.Second {
+			t.Fatal("Racks revealed before delay passed")
+		} else if len(u.Racks) != 2 || len(u.Racks[0]) != maxTiles {
+			t.Fatalf("Expected both racks in delayed update, got %v", u.Racks)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Racks were never revealed")
+	}
+
+	// Every subscriber is sent an update at once, so the player has had theirs
+	if n := len(playerUpdates); n != 1 {
+		t.Fatalf("Expected the player to be sent 1 update, got %v", n)
+	} else if e := <-playerUpdates; e.Data.(SpectatorUpdate).Racks != nil {
+		t.Fatal("Racks were revealed to a player")
+	}
+}
+
+func nextSpectatorUpdate(t *testing.T, events *bufio.Scanner) SpectatorUpdate {
+	var u SpectatorUpdate
+	for events.Scan() {
+		line := events.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &u); err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+	t.Fatal("Event stream ended unexpectedly")
+	return u
+}
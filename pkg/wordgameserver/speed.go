@@ -0,0 +1,204 @@
+package wordgameserver
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// speedStartTiles is the number of tiles each player starts with in the speed
+// variant
+const speedStartTiles = 21
+
+// speedDumpTiles is the number of tiles drawn when a player dumps one back
+const speedDumpTiles = 3
+
+// GridTile is a tile on a player's private grid in the speed variant
+type GridTile struct {
+	Row    int    `json:"row"`
+	Col    int    `json:"col"`
+	Letter string `json:"letter"`          // letter on the tile, a space for a blank
+	Blank  string `json:"blank,omitempty"` // letter a blank stands for
+}
+
+// validateSpeed checks that the rules can be used together with the speed
+// variant, in which every player builds at once
+func (r GameRules) validateSpeed() error {
+	if !r.Speed {
+		return nil
+	} else if r.ClockMinutes > 0 || r.Teams || r.Duplicate || r.TurnDeadlineHours > 0 {
+		return newError(CodeInvalidRules, "Speed games cannot use clocks, deadlines, teams or duplicate rounds")
+	}
+	return nil
+}
+
+// speedAction handles a request in the speed variant. Players peel once their
+// grid uses every tile they hold, which deals a tile to every player, or ends
+// the game if the bag cannot deal to everyone. Swapping a single tile dumps
+// it for three from the bag.
+func (sg *ScrabbleGame) speedAction(j GamePlayRequest) error {
+	p := sg.Players[j.PlayerID]
+
+	if sg.Winner != "" {
+		return errGameOver
+	}
+
+	switch {
+	case j.Swap:
+		return sg.dumpTile(p, j.Tiles)
+	case j.Peel:
+		words, err := speedGridWords(j.Grid, p.Tiles)
+		if err != nil {
+			return err
+		} else if err := sg.checkWords(words); err != nil {
+			return err
+		}
+		p.Grid = j.Grid
+
+		if len(sg.TileBag) < len(sg.Players) {
+			sg.Winner = p.Name
+			sg.Lock()
+			sg.Finished = true
+			sg.Unlock()
+			sg.history.record(GameEvent{
+				Type:   "bananas",
+				Player: p.Name,
+				Words:  words,
+			})
+			return nil
+		}
+
+		for _, other := range sg.Players {
+			dealTiles(other, &sg.TileBag, 1)
+		}
+		sg.history.record(GameEvent{
+			Type:   "peel",
+			Player: p.Name,
+		})
+		return nil
+	default:
+		// Save the grid so far without checking it
+		p.Grid = j.Grid
+		return nil
+	}
+}
+
+// dumpTile returns a tile to the bag in exchange for three others
+func (sg *ScrabbleGame) dumpTile(p *Player, t []byte) error {
+	if len(t) != 1 {
+		return errors.New("Exactly one tile can be dumped")
+	} else if len(sg.TileBag) < speedDumpTiles {
+		return newError(CodeNotEnoughTiles, "Not enough tiles available to dump")
+	}
+
+	if err := removeTiles(p, t); err != nil {
+		return err
+	}
+
+	dealTiles(p, &sg.TileBag, speedDumpTiles)
+	sg.TileBag = append(sg.TileBag, t...)
+	sg.TileBag.shuffle()
+
+	sg.history.record(GameEvent{
+		Type:   "dump",
+		Player: p.Name,
+		Count:  1,
+	})
+
+	return nil
+}
+
+// speedGridWords checks that a grid uses exactly the player's tiles with one
+// tile per square, and that the tiles are connected. It returns the words
+// formed across and down, in reading order, with blanks as the lowercase
+// letters they stand for.
+func speedGridWords(grid []GridTile, hand []byte) ([]string, error) {
+	if len(grid) != len(hand) {
+		return nil, newError(CodeInvalidPlacement, "Grid must use every tile in hand")
+	}
+
+	letters := make(map[SquareCoordinate]byte, len(grid))
+	played := make([]byte, 0, len(grid))
+	for _, gt := range grid {
+		sc := SquareCoordinate{Row: gt.Row, Col: gt.Col}
+		if len(gt.Letter) != 1 {
+			return nil, errors.New("Grid tiles must have one letter")
+		} else if _, ok := letters[sc]; ok {
+			return nil, newError(CodeInvalidPlacement, "Grid has two tiles on one square")
+		}
+
+		l := gt.Letter[0]
+		if l == ' ' {
+			if len(gt.Blank) != 1 {
+				return nil, errors.New("No letter given for blank")
+			}
+			l = strings.ToLower(gt.Blank)[0]
+			if l < 'a' || l > 'z' {
+				return nil, errors.New("Blank must be given a letter from A to Z")
+			}
+		}
+		letters[sc] = l
+		played = append(played, gt.Letter[0])
+	}
+
+	if err := removeTiles(&Player{Tiles: hand}, played); err != nil {
+		return nil, err
+	}
+
+	// Check every tile can be reached from the first
+	if len(grid) > 0 {
+		start := SquareCoordinate{Row: grid[0].Row, Col: grid[0].Col}
+		seen := map[SquareCoordinate]bool{start: true}
+		queue := []SquareCoordinate{start}
+		for len(queue) > 0 {
+			sc := queue[0]
+			queue = queue[1:]
+			for _, n := range []SquareCoordinate{
+				{Row: sc.Row - 1, Col: sc.Col}, {Row: sc.Row + 1, Col: sc.Col},
+				{Row: sc.Row, Col: sc.Col - 1}, {Row: sc.Row, Col: sc.Col + 1},
+			} {
+				if _, ok := letters[n]; ok && !seen[n] {
+					seen[n] = true
+					queue = append(queue, n)
+				}
+			}
+		}
+		if len(seen) != len(letters) {
+			return nil, newError(CodeInvalidPlacement, "Grid tiles are not all connected")
+		}
+	}
+
+	// Read the words from the start of each run of two or more tiles
+	squares := make([]SquareCoordinate, 0, len(letters))
+	for sc := range letters {
+		squares = append(squares, sc)
+	}
+	sort.Slice(squares, func(a, b int) bool {
+		if squares[a].Row != squares[b].Row {
+			return squares[a].Row < squares[b].Row
+		}
+		return squares[a].Col < squares[b].Col
+	})
+
+	var words []string
+	for _, dir := range [][2]int{{0, 1}, {1, 0}} {
+		for _, sc := range squares {
+			if _, ok := letters[SquareCoordinate{Row: sc.Row - dir[0], Col: sc.Col - dir[1]}]; ok {
+				continue
+			}
+			var word []byte
+			for cur := sc; ; cur = (SquareCoordinate{Row: cur.Row + dir[0], Col: cur.Col + dir[1]}) {
+				l, ok := letters[cur]
+				if !ok {
+					break
+				}
+				word = append(word, l)
+			}
+			if len(word) > 1 {
+				words = append(words, string(word))
+			}
+		}
+	}
+
+	return words, nil
+}
@@ -0,0 +1,127 @@
+package wordgameserver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSpeedGridWords(t *testing.T) {
+	// C A T
+	// A
+	// B
+	grid := []GridTile{
+		{Row: 0, Col: 0, Letter: "C"},
+		{Row: 0, Col: 1, Letter: "A"},
+		{Row: 0, Col: 2, Letter: "T"},
+		{Row: 1, Col: 0, Letter: "A"},
+		{Row: 2, Col: 0, Letter: "B"},
+	}
+
+	words, err := speedGridWords(grid, []byte("TACAB"))
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(words, []string{"CAT", "CAB"}) {
+		t.Errorf("Expected words CAT and CAB, got %v", words)
+	}
+
+	if _, err := speedGridWords(grid, []byte("TACABS")); err == nil {
+		t.Error("Grid should have to use every tile")
+	}
+
+	// A blank is read as the letter it stands for
+	grid[4] = GridTile{Row: 2, Col: 0, Letter: " ", Blank: "B"}
+	words, err = speedGridWords(grid, []byte("TACA "))
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(words, []string{"CAT", "CAb"}) {
+		t.Errorf("Expected words CAT and CAb, got %v", words)
+	}
+
+	grid[4].Blank = ""
+	if _, err := speedGridWords(grid, []byte("TACA ")); err == nil {
+		t.Error("Blank without a letter should fail")
+	}
+
+	grid[4] = GridTile{Row: 5, Col: 5, Letter: "B"}
+	if _, err := speedGridWords(grid, []byte("TACAB")); err == nil {
+		t.Error("Disconnected grid should fail")
+	}
+}
+
+func TestSpeedGame(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.Speed = true
+
+	playerIDs := make([]uuid.UUID, 2)
+	for i, name := range []string{"ashley1", "ashley2"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	// Leave enough tiles for one peel, then bananas
+	newGame.TileBag = newGame.TileBag[:2*speedStartTiles+3]
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	peel := func(id uuid.UUID) (GameStateResponse, error) {
+		s, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: id})
+		if err != nil {
+			return s, err
+		}
+		return newGame.request(GamePlayRequest{
+			GameID:   newGame.ID,
+			PlayerID: id,
+			Grid:     lineGrid(s.PlayerTiles),
+			Peel:     true,
+			Play:     true,
+		})
+	}
+
+	s, err := peel(playerIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	} else if len(s.PlayerTiles) != speedStartTiles+1 {
+		t.Fatalf("Expected %v tiles after peel, got %v", speedStartTiles+1, len(s.PlayerTiles))
+	}
+
+	other, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[1]})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(other.PlayerTiles) != speedStartTiles+1 {
+		t.Fatal("Peel did not deal a tile to every player")
+	}
+
+	// Second player's grid no longer uses all their tiles
+	_, err = newGame.request(GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: playerIDs[1],
+		Grid:     lineGrid(other.PlayerTiles[1:]),
+		Peel:     true,
+		Play:     true,
+	})
+	if err == nil {
+		t.Fatal("Peel should fail unless every tile is on the grid")
+	}
+
+	s, err = peel(playerIDs[1])
+	if err != nil {
+		t.Fatal(err)
+	} else if s.Winner != "ashley2" {
+		t.Fatalf("Expected ashley2 to win, winner is %q", s.Winner)
+	}
+}
+
+// lineGrid lays tiles out in a single row, with blanks standing for E
+func lineGrid(tiles []byte) []GridTile {
+	grid := make([]GridTile, len(tiles))
+	for i, t := range tiles {
+		grid[i] = GridTile{Row: 0, Col: i, Letter: string(t)}
+		if t == ' ' {
+			grid[i].Blank = "E"
+		}
+	}
+	return grid
+}
@@ -0,0 +1,385 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Defaults for study quizzes
+const (
+	defaultStudyLength = 7   // word length quizzed, bingos by default
+	defaultStudyLimit  = 100 // most probable alphagrams a quiz draws from
+	defaultStudyCount  = 10  // questions in a quiz
+)
+
+// cardboxIntervals is how long a card waits before it is due again after it
+// is answered correctly and moves into each box. Missed cards go back to the
+// first box.
+var cardboxIntervals = []time.Duration{
+	1 * 24 * time.Hour,
+	4 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	12 * 24 * time.Hour,
+	20 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	60 * 24 * time.Hour,
+	90 * 24 * time.Hour,
+	150 * 24 * time.Hour,
+	270 * 24 * time.Hour,
+}
+
+// StudyRequest is the format of the request a client sends to get a quiz,
+// answer a question or see their cardbox
+type StudyRequest struct {
+	User       string   `json:"user"`
+	Dictionary string   `json:"dictionary"`
+	Length     int      `json:"length,omitempty"`    // word length to quiz on
+	Limit      int      `json:"limit,omitempty"`     // quiz the most probable alphagrams up to this many
+	Count      int      `json:"count,omitempty"`     // questions to return
+	Alphagram  string   `json:"alphagram,omitempty"` // question being answered
+	Words      []string `json:"words,omitempty"`     // answer given
+}
+
+// StudyQuestion is an alphagram to find the anagrams of
+type StudyQuestion struct {
+	Alphagram string     `json:"alphagram"`
+	Answers   int        `json:"answers"`       // number of words to find
+	Rank      int        `json:"rank"`          // position in probability order, 1 for the most probable
+	Box       int        `json:"box"`           // cardbox the question is in, -1 if it is new
+	Due       *time.Time `json:"due,omitempty"` // when a card already in the cardbox came due
+}
+
+// StudyResult is the result of answering a question
+type StudyResult struct {
+	Alphagram string    `json:"alphagram"`
+	Correct   bool      `json:"correct"`
+	Answers   []string  `json:"answers"` // every valid word
+	Missed    []string  `json:"missed"`  // valid words not given
+	Wrong     []string  `json:"wrong"`   // words given that are not valid
+	Box       int       `json:"box"`
+	Due       time.Time `json:"due"`
+}
+
+// CardboxSummary is the number of cards a user has in each box
+type CardboxSummary struct {
+	User       string `json:"user"`
+	Dictionary string `json:"dictionary"`
+	Boxes      []int  `json:"boxes"` // cards in each box
+	Due        int    `json:"due"`   // cards due now
+}
+
+// studyCard is a user's progress on one alphagram
+type studyCard struct {
+	box       int
+	due       time.Time
+	correct   int
+	incorrect int
+}
+
+// studyListKey identifies the alphagrams of one length in a dictionary
+type studyListKey struct {
+	dictionary *Dictionary
+	length     int
+}
+
+// cardbox holds a user's cards for one dictionary, keyed by alphagram
+type cardbox map[string]*studyCard
+
+var (
+	studyMu   sync.Mutex
+	cardboxes = make(map[string]cardbox)              // keyed by user and dictionary
+	studyList = make(map[studyListKey][]string)       // alphagrams in probability order
+	studyRank = make(map[studyListKey]map[string]int) // position of each alphagram in studyList
+)
+
+// cardboxKey is the key a user's cardbox is stored under
+func cardboxKey(user, dictionary string) string {
+	return user + "\x00" + dictionary
+}
+
+// alphagramProbability returns the number of ways the alphagram can be drawn
+// from a full tile bag without using blanks
+func alphagramProbability(a string) float64 {
+	var need [26]int
+	for i := 0; i < len(a); i++ {
+		need[a[i]-'A']++
+	}
+
+	p := 1.0
+	for l, n := range need {
+		count := tiles['A'+byte(l)].Count
+		for k := 0; k < n; k++ {
+			p *= float64(count-k) / float64(k+1)
+		}
+	}
+	return p
+}
+
+// probabilityOrder returns the dictionary's alphagrams of the length, most
+// probable first. The caller must hold studyMu.
+func probabilityOrder(d *Dictionary, length int) ([]string, map[string]int) {
+	key := studyListKey{d, length}
+	if list, ok := studyList[key]; ok {
+		return list, studyRank[key]
+	}
+
+	var list []string
+	prob := make(map[string]float64)
+	for a := range d.anagrams {
+		if len(a) == length {
+			list = append(list, a)
+			prob[a] = alphagramProbability(a)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if prob[list[i]] != prob[list[j]] {
+			return prob[list[i]] > prob[list[j]]
+		}
+		return list[i] < list[j]
+	})
+
+	rank := make(map[string]int, len(list))
+	for i, a := range list {
+		rank[a] = i + 1
+	}
+
+	studyList[key], studyRank[key] = list, rank
+	return list, rank
+}
+
+// studyQuiz returns questions for the user, starting with their cards that are
+// due, oldest first, followed by new alphagrams in probability order
+func studyQuiz(j StudyRequest, d *Dictionary, now time.Time) []StudyQuestion {
+	studyMu.Lock()
+	defer studyMu.Unlock()
+
+	list, rank := probabilityOrder(d, j.Length)
+	if len(list) > j.Limit {
+		list = list[:j.Limit]
+	}
+	cb := cardboxes[cardboxKey(j.User, d.Name)]
+
+	var due []string
+	for a, c := range cb {
+		if len(a) == j.Length && !c.due.After(now) {
+			due = append(due, a)
+		}
+	}
+	sort.Slice(due, func(i, k int) bool {
+		if !cb[due[i]].due.Equal(cb[due[k]].due) {
+			return cb[due[i]].due.Before(cb[due[k]].due)
+		}
+		return rank[due[i]] < rank[due[k]]
+	})
+
+	questions := []StudyQuestion{}
+	for _, a := range due {
+		if len(questions) == j.Count {
+			return questions
+		}
+		due := cb[a].due
+		questions = append(questions, StudyQuestion{
+			Alphagram: a,
+			Answers:   len(d.anagrams[a]),
+			Rank:      rank[a],
+			Box:       cb[a].box,
+			Due:       &due,
+		})
+	}
+	for _, a := range list {
+		if len(questions) == j.Count {
+			break
+		}
+		if _, ok := cb[a]; ok {
+			continue
+		}
+		questions = append(questions, StudyQuestion{
+			Alphagram: a,
+			Answers:   len(d.anagrams[a]),
+			Rank:      rank[a],
+			Box:       -1,
+		})
+	}
+
+	return questions
+}
+
+// studyAnswer grades the user's answer to a question and moves the card to its
+// next box, or back to the first box if any word was missed or wrong
+func studyAnswer(j StudyRequest, d *Dictionary, now time.Time) (StudyResult, error) {
+	a := alphagram(j.Alphagram)
+	answers, ok := d.anagrams[a]
+	if !ok {
+		return StudyResult{}, errors.New("'" + a + "' has no anagrams in the dictionary")
+	}
+
+	r := StudyResult{
+		Alphagram: a,
+		Answers:   answers,
+		Missed:    []string{},
+		Wrong:     []string{},
+	}
+	given := make(map[string]bool)
+	for _, w := range j.Words {
+		w = strings.ToUpper(w)
+		if given[w] {
+			continue
+		}
+		given[w] = true
+		if alphagram(w) != a || !d.words[w] {
+			r.Wrong = append(r.Wrong, w)
+		}
+	}
+	for _, w := range answers {
+		if !given[w] {
+			r.Missed = append(r.Missed, w)
+		}
+	}
+	r.Correct = len(r.Missed) == 0 && len(r.Wrong) == 0
+
+	studyMu.Lock()
+	defer studyMu.Unlock()
+
+	key := cardboxKey(j.User, d.Name)
+	cb, ok := cardboxes[key]
+	if !ok {
+		cb = make(cardbox)
+		cardboxes[key] = cb
+	}
+	c, ok := cb[a]
+	if !ok {
+		c = &studyCard{box: -1}
+		cb[a] = c
+	}
+
+	if r.Correct {
+		c.correct++
+		if c.box < len(cardboxIntervals)-1 {
+			c.box++
+		}
+	} else {
+		c.incorrect++
+		c.box = 0
+	}
+	c.due = now.Add(cardboxIntervals[c.box])
+	saveCardboxes()
+
+	r.Box, r.Due = c.box, c.due
+	return r, nil
+}
+
+// cardboxSummary counts the user's cards in each box and how many are due
+func cardboxSummary(j StudyRequest, d *Dictionary, now time.Time) CardboxSummary {
+	studyMu.Lock()
+	defer studyMu.Unlock()
+
+	s := CardboxSummary{
+		User:       j.User,
+		Dictionary: d.Name,
+		Boxes:      make([]int, len(cardboxIntervals)),
+	}
+	for _, c := range cardboxes[cardboxKey(j.User, d.Name)] {
+		s.Boxes[c.box]++
+		if !c.due.After(now) {
+			s.Due++
+		}
+	}
+	return s
+}
+
+// studyRequestHelper decodes a study request, fills in its defaults and finds
+// the dictionary it asks for
+func studyRequestHelper(j *StudyRequest, w http.ResponseWriter, r *http.Request) (*Dictionary, bool) {
+	err := json.NewDecoder(r.Body).Decode(j)
+	if err != nil {
+		writeError(w, err)
+		return nil, false
+	}
+
+	if j.User == "" {
+		writeError(w, newError(CodeBadRequest, "User must be provided"))
+		return nil, false
+	}
+	if j.Length == 0 {
+		j.Length = defaultStudyLength
+	}
+	if j.Limit == 0 {
+		j.Limit = defaultStudyLimit
+	}
+	if j.Count == 0 {
+		j.Count = defaultStudyCount
+	}
+	if j.Length < 2 || j.Length > rowCount || j.Limit < 0 || j.Count < 0 {
+		writeError(w, newError(CodeBadRequest, "Invalid quiz length, limit or count"))
+		return nil, false
+	}
+
+	d, ok := getDictionary(j.Dictionary)
+	if !ok {
+		writeError(w, unknownDictionary(j.Dictionary))
+		return nil, false
+	}
+
+	return d, true
+}
+
+// writeStudyResponse sends the response to a study request
+func writeStudyResponse(w http.ResponseWriter, v interface{}) {
+	resp, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
+
+// studyQuizHandler handles requests for quiz questions
+func studyQuizHandler(w http.ResponseWriter, r *http.Request) {
+	var j StudyRequest
+
+	d, ok := studyRequestHelper(&j, w, r)
+	if !ok {
+		return
+	}
+
+	writeStudyResponse(w, studyQuiz(j, d, time.Now()))
+}
+
+// studyAnswerHandler handles answers to quiz questions
+func studyAnswerHandler(w http.ResponseWriter, r *http.Request) {
+	var j StudyRequest
+
+	d, ok := studyRequestHelper(&j, w, r)
+	if !ok {
+		return
+	}
+
+	result, err := studyAnswer(j, d, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeStudyResponse(w, result)
+}
+
+// cardboxHandler handles requests for a summary of a user's cardbox
+func cardboxHandler(w http.ResponseWriter, r *http.Request) {
+	var j StudyRequest
+
+	d, ok := studyRequestHelper(&j, w, r)
+	if !ok {
+		return
+	}
+
+	writeStudyResponse(w, cardboxSummary(j, d, time.Now()))
+}
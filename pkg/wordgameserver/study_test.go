@@ -0,0 +1,99 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProbabilityOrder(t *testing.T) {
+	d := testDictionary(t)
+
+	studyMu.Lock()
+	list, rank := probabilityOrder(d, 3)
+	studyMu.Unlock()
+
+	// ACT is far more likely to be drawn than AOZ
+	if len(list) != 2 || list[0] != "ACT" || list[1] != "AOZ" {
+		t.Errorf("Three-letter alphagrams in probability order are %v", list)
+	} else if rank["AOZ"] != 2 {
+		t.Errorf("AOZ ranked %v, expected 2", rank["AOZ"])
+	}
+}
+
+func TestCardbox(t *testing.T) {
+	d := testDictionary(t)
+	now := time.Now()
+	j := StudyRequest{User: "TestCardbox", Length: 3, Limit: 10, Count: 10}
+
+	questions := studyQuiz(j, d, now)
+	if len(questions) != 2 || questions[0].Alphagram != "ACT" || questions[0].Answers != 3 || questions[0].Box != -1 {
+		t.Fatalf("Unexpected new questions %+v", questions)
+	}
+
+	// Answering correctly moves the card up a box
+	j.Alphagram, j.Words = "TCA", []string{"act", "cat", "tac"}
+	r, err := studyAnswer(j, d, now)
+	if err != nil {
+		t.Fatal(err)
+	} else if !r.Correct || r.Box != 0 || !r.Due.Equal(now.Add(cardboxIntervals[0])) {
+		t.Errorf("Unexpected result for a correct answer %+v", r)
+	}
+
+	// The card is not asked again until it is due
+	if questions = studyQuiz(j, d, now); len(questions) != 1 || questions[0].Alphagram != "AOZ" {
+		t.Errorf("Expected only AOZ before ACT is due, got %+v", questions)
+	}
+	if questions = studyQuiz(j, d, r.Due); len(questions) != 2 || questions[0].Alphagram != "ACT" || questions[0].Box != 0 {
+		t.Errorf("Expected ACT first once due, got %+v", questions)
+	}
+
+	r, _ = studyAnswer(j, d, r.Due)
+	if r.Box != 1 {
+		t.Errorf("Card is in box %v, expected 1", r.Box)
+	}
+
+	// Missing a word sends the card back to the first box
+	j.Words = []string{"CAT", "TCA"}
+	r, _ = studyAnswer(j, d, r.Due)
+	if r.Correct || r.Box != 0 || len(r.Missed) != 2 || len(r.Wrong) != 1 {
+		t.Errorf("Unexpected result for a wrong answer %+v", r)
+	}
+
+	s := cardboxSummary(j, d, now)
+	if s.Boxes[0] != 1 || s.Due != 0 {
+		t.Errorf("Unexpected cardbox summary %+v", s)
+	}
+
+	if _, err = studyAnswer(StudyRequest{User: "TestCardbox", Alphagram: "DOG"}, d, now); err == nil {
+		t.Error("Answer for an alphagram with no anagrams was accepted")
+	}
+}
+
+func TestStudyQuizHandler(t *testing.T) {
+	testDictionary(t)
+
+	body, _ := json.Marshal(StudyRequest{User: "TestStudyQuizHandler", Dictionary: "test", Length: 2})
+	rr := httptest.NewRecorder()
+	studyQuizHandler(rr, httptest.NewRequest("GET", "/study/quiz", bytes.NewReader(body)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", rr.Code, http.StatusOK)
+	}
+
+	var questions []StudyQuestion
+	if err := json.NewDecoder(rr.Body).Decode(&questions); err != nil {
+		t.Fatal(err)
+	} else if len(questions) != 1 || questions[0].Alphagram != "AT" || questions[0].Answers != 2 {
+		t.Errorf("Unexpected questions %+v", questions)
+	}
+
+	body, _ = json.Marshal(StudyRequest{Dictionary: "test"})
+	rr = httptest.NewRecorder()
+	studyQuizHandler(rr, httptest.NewRequest("GET", "/study/quiz", bytes.NewReader(body)))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Returned status code %v, expected %v", rr.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,145 @@
+package wordgameserver
+
+import (
+	"github.com/google/uuid"
+)
+
+// teamCount is the number of teams in a team game, each with two players
+const teamCount = 2
+
+// TeamScore is the shared score of a team
+type TeamScore struct {
+	Team    int      `json:"team"`    // team number, starting at 1
+	Players []string `json:"players"` // names of the players on the team
+	Score   int      `json:"score"`   // combined score of the team's players
+}
+
+// validateTeams checks that a team game has the players to fill every team
+func (sg *ScrabbleGame) validateTeams() error {
+	if sg.Rules.Teams && len(sg.Players) != teamCount*2 {
+		return newError(CodeNotEnoughPlayers, "Team games need exactly four players")
+	}
+	return nil
+}
+
+// addTeamPlayer adds a player to the game, on the team they chose if they
+// chose one. Players who do not choose are put on a team with room when the
+// game starts.
+func (sg *ScrabbleGame) addTeamPlayer(name string, team *int) (uuid.UUID, error) {
+	if team == nil {
+		return sg.addPlayer(name)
+	}
+
+	if !sg.Rules.Teams {
+		return uuid.Nil, newError(CodeInvalidRules, "Teams can only be chosen in team games")
+	} else if *team < 1 || *team > teamCount {
+		return uuid.Nil, newError(CodeBadRequest, "Team must be 1 or 2")
+	}
+
+	chosen := 0
+	for _, p := range sg.Players {
+		if p.teamChoice == *team {
+			chosen++
+		}
+	}
+	if chosen == 2 {
+		return uuid.Nil, newError(CodeGameFull, "Team is full")
+	}
+
+	id, err := sg.addPlayer(name)
+	if err != nil {
+		return id, err
+	}
+	sg.Players[id].teamChoice = *team
+	return id, nil
+}
+
+// assignTeams puts players on the teams they chose, and the rest on whichever
+// team has room. Teammates are then seated opposite each other, so turns
+// alternate between the teams and each teammate plays every other turn for
+// their team. The first player keeps the first turn.
+func (sg *ScrabbleGame) assignTeams() {
+	if !sg.Rules.Teams {
+		return
+	}
+
+	playerList := sg.playerList()
+
+	counts := make([]int, teamCount+1)
+	for _, p := range playerList {
+		p.Team = p.teamChoice
+		counts[p.Team]++
+	}
+	for _, p := range playerList {
+		if p.Team != 0 {
+			continue
+		}
+		p.Team = 1
+		for t := 2; t <= teamCount; t++ {
+			if counts[t] < counts[p.Team] {
+				p.Team = t
+			}
+		}
+		counts[p.Team]++
+	}
+
+	// Each team keeps its players in turn order
+	teams := make([][]*Player, teamCount+1)
+	for _, p := range playerList {
+		teams[p.Team] = append(teams[p.Team], p)
+	}
+
+	order := []int{playerList[0].Team}
+	for t := 1; t <= teamCount; t++ {
+		if t != order[0] {
+			order = append(order, t)
+		}
+	}
+	for i, t := range order {
+		for seat, p := range teams[t] {
+			p.Number = seat*teamCount + i
+		}
+	}
+}
+
+// teamScores totals the scores of each team's players
+func teamScores(playerList []*Player) []TeamScore {
+	var scores []TeamScore
+	for _, p := range playerList {
+		if p.Team == 0 {
+			continue
+		}
+		for len(scores) < p.Team {
+			scores = append(scores, TeamScore{Team: len(scores) + 1})
+		}
+		ts := &scores[p.Team-1]
+		ts.Players = append(ts.Players, p.Name)
+		ts.Score += p.Score
+	}
+	return scores
+}
+
+// teammate returns the other player on the player's team, or nil if the game
+// is not a team game
+func teammate(playerList []*Player, p *Player) *Player {
+	if p.Team == 0 {
+		return nil
+	}
+	for _, other := range playerList {
+		if other != p && other.Team == p.Team {
+			return other
+		}
+	}
+	return nil
+}
+
+// playerTeam looks up the team of a player in the game, which is zero if the
+// game is not a team game
+func (sg *ScrabbleGame) playerTeam(playerID uuid.UUID) int {
+	sg.Lock()
+	defer sg.Unlock()
+	if p, ok := sg.Players[playerID]; ok {
+		return p.Team
+	}
+	return 0
+}
@@ -0,0 +1,220 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTeamGame(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.Teams = true
+	newGame.Rules.TeamRackSharing = true
+	newGame.Rules.TurnOrder = turnOrderDraw
+
+	playerIDs := make([]uuid.UUID, 4)
+	for i, name := range []string{"ashley1", "ashley2", "ashley3"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	if err := newGame.start(); err == nil {
+		t.Fatal("Team game should not start with three players")
+	} else if len(newGame.history.list()) != 0 {
+		t.Fatal("Players drew for first before the game was known to be able to start")
+	}
+
+	newGame.Rules.TurnOrder = turnOrderJoin
+
+	playerIDs[3], _ = newGame.addPlayer("ashley4")
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	states := make([]GameStateResponse, 4)
+	for i, id := range playerIDs {
+		s, err := newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		states[i] = s
+	}
+
+	// Teammates sit opposite each other, so turns alternate between teams
+	for i, p := range states[0].Players {
+		if p.Team != i%2+1 {
+			t.Fatalf("Player %v is on team %v", i, p.Team)
+		}
+	}
+
+	if len(states[0].Teams) != 2 || len(states[0].Teams[0].Players) != 2 {
+		t.Fatalf("Expected two teams of two, got %v", states[0].Teams)
+	}
+
+	if !bytes.Equal(states[0].TeammateTiles, states[2].PlayerTiles) {
+		t.Fatal("Player cannot see their teammate's rack")
+	}
+
+	u, err := unseenTiles(states[0])
+	if err != nil {
+		t.Fatal(err)
+	} else if u.Total != len(initializedTileBag)-2*maxTiles {
+		t.Errorf("Expected teammate's rack to be seen, %v tiles unseen", u.Total)
+	}
+}
+
+func TestTeamChoice(t *testing.T) {
+	newGame := createScrabbleGame()
+	two := 2
+
+	if _, err := newGame.addTeamPlayer("ashley1", &two); errorCode(err) != CodeInvalidRules {
+		t.Fatalf("Expected a team choice outside a team game to fail with %v, got %v", CodeInvalidRules, err)
+	}
+
+	newGame.Rules.Teams = true
+
+	// The first two players choose the same team and the others take what is
+	// left
+	playerIDs := make([]uuid.UUID, 4)
+	for i, team := range []*int{&two, &two, nil, nil} {
+		id, err := newGame.addTeamPlayer("ashley"+strconv.Itoa(i+1), team)
+		if err != nil {
+			t.Fatal(err)
+		}
+		playerIDs[i] = id
+	}
+
+	three := 3
+	if _, err := newGame.addTeamPlayer("ashley5", &three); errorCode(err) != CodeBadRequest {
+		t.Errorf("Expected an unknown team to fail with %v, got %v", CodeBadRequest, err)
+	} else if _, err := newGame.addTeamPlayer("ashley5", &two); errorCode(err) != CodeGameFull {
+		t.Errorf("Expected a full team to fail with %v, got %v", CodeGameFull, err)
+	}
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first player keeps the first turn, with their chosen teammate
+	// sitting opposite them
+	expected := []struct {
+		number int
+		team   int
+	}{{0, 2}, {2, 2}, {1, 1}, {3, 1}}
+	for i, id := range playerIDs {
+		p := newGame.Players[id]
+		if p.Number != expected[i].number || p.Team != expected[i].team {
+			t.Errorf("Player %v is number %v on team %v, expected number %v on team %v",
+				i, p.Number, p.Team, expected[i].number, expected[i].team)
+		}
+	}
+}
+
+func TestTeamChat(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.Teams = true
+
+	playerIDs := make([]uuid.UUID, 4)
+	for i, name := range []string{"ashley1", "ashley2", "ashley3", "ashley4"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	if _, err := newGame.postChat(ChatRequest{PlayerID: &playerIDs[0], Text: "hi", TeamOnly: true}); err == nil {
+		t.Fatal("Team chat should not be available before teams are assigned")
+	}
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newGame.postChat(ChatRequest{PlayerID: &playerIDs[0], Text: "play the Q", TeamOnly: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	teammate := newGame.chat.since(0, newGame.playerTeam(playerIDs[2]))
+	opponent := newGame.chat.since(0, newGame.playerTeam(playerIDs[1]))
+	spectator := newGame.chat.since(0, 0)
+
+	if len(teammate) != 1 {
+		t.Fatal("Teammate cannot see team chat")
+	} else if len(opponent) != 0 || len(spectator) != 0 {
+		t.Fatal("Team chat is visible outside the team")
+	}
+
+	// Chat is kept in the history, as far as each reader can see it
+	if _, err := newGame.postChat(ChatRequest{PlayerID: &playerIDs[1], Text: "good luck"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		playerID *uuid.UUID
+		chat     []string
+	}{
+		{&playerIDs[2], []string{"play the Q", "good luck"}},
+		{&playerIDs[1], []string{"good luck"}},
+		{nil, nil}, // spectators cannot read the chat in this game
+	} {
+		events, err := newGame.visibleHistory(test.playerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var chat []string
+		for _, e := range events {
+			if e.Type == "chat" {
+				chat = append(chat, e.Text)
+			}
+		}
+		if !reflect.DeepEqual(chat, test.chat) {
+			t.Errorf("Expected chat %q in history, got %q", test.chat, chat)
+		}
+	}
+}
+
+func TestTeamEndGame(t *testing.T) {
+	newGame := createScrabbleGame()
+	newGame.Rules.Teams = true
+	newGame.TileBag = TileBag(strings.Repeat("E", 4*maxTiles))
+
+	playerIDs := make([]uuid.UUID, 4)
+	for i, name := range []string{"ashley1", "ashley2", "ashley3", "ashley4"} {
+		playerIDs[i], _ = newGame.addPlayer(name)
+	}
+
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Going out with the bag empty ends the game
+	s, err := newGame.request(GamePlayRequest{
+		GameID:   newGame.ID,
+		PlayerID: playerIDs[0],
+		StartPos: SquareCoordinate{Row: 7, Col: 4},
+		EndPos:   SquareCoordinate{Row: 7, Col: 10},
+		Tiles:    []byte("EEEEEEE"),
+		Play:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The player who went out gains only the opponents' racks, and the
+	// teammate's rack counts against the team
+	rack := rackValue([]byte("EEEEEEE"))
+	played := s.Players[0].Score - 2*rack
+	if s.Players[1].Score != -rack || s.Players[2].Score != -rack || s.Players[3].Score != -rack {
+		t.Fatalf("Racks were not deducted: %v", s.Players)
+	} else if s.Teams[0].Score != played+rack || s.Teams[1].Score != -2*rack {
+		t.Fatalf("Expected team scores %v and %v, got %v", played+rack, -2*rack, s.Teams)
+	} else if s.Winner != "ashley1 & ashley3" {
+		t.Fatalf("Expected team 1 to win, got %q", s.Winner)
+	}
+
+	_, err = newGame.request(GamePlayRequest{GameID: newGame.ID, PlayerID: playerIDs[1], Play: true})
+	if errorCode(err) != CodeGameOver {
+		t.Fatalf("Expected GAME_OVER after the game ended, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package wordgameserver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// squareMarkers is the character drawn for each type of empty square in text
+// boards
+var squareMarkers = map[string]byte{
+	"plain":        ' ',
+	"star":         '*',
+	"doubleLetter": '\'',
+	"tripleLetter": '"',
+	"doubleWord":   '-',
+	"tripleWord":   '=',
+}
+
+// ANSI escape codes used for colored text boards
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[31m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+	ansiTile    = "\x1b[1;30;43m" // bold black on yellow
+)
+
+// squareColorCodes is the ANSI color of each type of empty square
+var squareColorCodes = map[string]string{
+	"star":         ansiMagenta,
+	"doubleLetter": ansiCyan,
+	"tripleLetter": ansiBlue,
+	"doubleWord":   ansiMagenta,
+	"tripleWord":   ansiRed,
+}
+
+// String draws the board as plain text, with blanks in lowercase
+func (sb ScrabbleBoard) String() string {
+	return sb.text(false)
+}
+
+// text draws the board with column letters and row numbers, optionally
+// colored with ANSI escape codes
+func (sb *ScrabbleBoard) text(color bool) string {
+	var b strings.Builder
+
+	b.WriteString("   ")
+	for c := 0; c < columnCount; c++ {
+		if c > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteByte(byte('A' + c))
+	}
+	b.WriteString("\n  +" + strings.Repeat("-", 2*columnCount-1) + "+\n")
+
+	for r, row := range sb {
+		fmt.Fprintf(&b, "%2d|", r+1)
+		for c, square := range row {
+			if c > 0 {
+				b.WriteByte(' ')
+			}
+
+			letter, code := squareMarkers[square.SquareType], squareColorCodes[square.SquareType]
+			if square.Letter != 0 {
+				letter, code = square.Letter, ansiTile
+				if square.Value == 0 {
+					letter += 'a' - 'A'
+				}
+			}
+
+			if color && code != "" {
+				b.WriteString(code + string(letter) + ansiReset)
+			} else {
+				b.WriteByte(letter)
+			}
+		}
+		fmt.Fprintf(&b, "|%d\n", r+1)
+	}
+
+	b.WriteString("  +" + strings.Repeat("-", 2*columnCount-1) + "+\n")
+
+	return b.String()
+}
+
+// String draws the game state as plain text: the board, the scores, and the
+// player's rack
+func (s GameStateResponse) String() string {
+	return s.text(false)
+}
+
+// text draws the game state, optionally colored with ANSI escape codes. The
+// player whose turn it is is marked with an arrow.
+func (s GameStateResponse) text(color bool) string {
+	var b strings.Builder
+
+	b.WriteString(s.Board.text(color))
+	b.WriteByte('\n')
+
+	for _, p := range s.Players {
+		marker := "  "
+		if p.Number == s.PlayerTurn && s.Winner == "" {
+			marker = "> "
+		}
+		name := p.Name
+		if p.ID == s.PlayerID {
+			name += " (you)"
+		}
+		name = fmt.Sprintf("%-20s", name)
+		if color && p.Number == s.PlayerTurn {
+			name = ansiBold + name + ansiReset
+		}
+		fmt.Fprintf(&b, "%s%s %4d", marker, name, p.Score)
+		if p.Forfeited {
+			b.WriteString("  forfeited")
+		}
+		b.WriteByte('\n')
+	}
+	for _, t := range s.Teams {
+		fmt.Fprintf(&b, "  Team %d: %-14s %4d\n", t.Team, strings.Join(t.Players, " & "), t.Score)
+	}
+
+	fmt.Fprintf(&b, "\nRack: %s\n", rackText(s.PlayerTiles, color))
+	if len(s.TeammateTiles) > 0 {
+		fmt.Fprintf(&b, "Teammate's rack: %s\n", rackText(s.TeammateTiles, color))
+	}
+	fmt.Fprintf(&b, "Tiles in bag: %d\n", s.TilesRemaining)
+	if s.Winner != "" {
+		fmt.Fprintf(&b, "Winner: %s\n", s.Winner)
+	}
+
+	return b.String()
+}
+
+// rackText writes a rack with blanks as ?, optionally drawn as tiles with
+// ANSI escape codes
+func rackText(rack []byte, color bool) string {
+	letters := strings.Replace(string(rack), " ", "?", -1)
+	if color && letters != "" {
+		return ansiTile + letters + ansiReset
+	}
+	return letters
+}
@@ -0,0 +1,93 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBoardString(t *testing.T) {
+	board := initializedBoard
+	board[7][7].Tile = tiles['H']
+	board[7][8].Tile = Tile{Letter: 'I'}
+
+	lines := strings.Split(board.String(), "\n")
+	if len(lines) != rowCount+4 {
+		t.Fatalf("Board has %v lines, expected %v", len(lines), rowCount+4)
+	}
+
+	expected := map[int]string{
+		0: "   A B C D E F G H I J K L M N O",
+		2: " 1|=     '       =       '     =|1",
+		3: " 2|  -       \"       \"       -  |2",
+		9: " 8|=     '       H i     '     =|8",
+	}
+	for i, want := range expected {
+		if lines[i] != want {
+			t.Errorf("Line %v is %q, expected %q", i, lines[i], want)
+		}
+	}
+
+	if colored := board.text(true); !strings.Contains(colored, ansiTile+"H"+ansiReset) || !strings.Contains(colored, ansiRed+"="+ansiReset) {
+		t.Error("Colored board is missing ANSI codes")
+	}
+}
+
+func TestGameStateString(t *testing.T) {
+	you, other := uuid.New(), uuid.New()
+	s := GameStateResponse{
+		PlayerID: you,
+		Players: []*Player{
+			{ID: you, Name: "Ann", Number: 0, Score: 12},
+			{ID: other, Name: "Ben", Number: 1, Score: 30},
+		},
+		Board:          initializedBoard,
+		PlayerTurn:     1,
+		PlayerTiles:    []byte("AB CDEF"),
+		TilesRemaining: 80,
+	}
+
+	text := s.String()
+	for _, want := range []string{
+		"  Ann (you)              12\n",
+		"> Ben                    30\n",
+		"Rack: AB?CDEF\n",
+		"Tiles in bag: 80\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("State text does not contain %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestGameStateTextFormat(t *testing.T) {
+	newGame := createScrabbleGame()
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	playerID, _ := newGame.addPlayer("ashley1")
+	newGame.addPlayer("ashley2")
+	newGame.start()
+
+	payload, _ := json.Marshal(GeneralGameRequest{GameID: newGame.ID, PlayerID: &playerID})
+
+	rr := httptest.NewRecorder()
+	gameStateHandler(rr, httptest.NewRequest("GET", "/game/state?format=text", bytes.NewReader(payload)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v", rr.Code, http.StatusOK)
+	} else if body := rr.Body.String(); !strings.HasPrefix(body, "   A B C") || !strings.Contains(body, "> ashley1 (you)") {
+		t.Errorf("Unexpected text state:\n%s", body)
+	}
+
+	rr = httptest.NewRecorder()
+	gameStateHandler(rr, httptest.NewRequest("GET", "/game/state?format=xml", bytes.NewReader(payload)))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Returned status code %v, expected %v", rr.Code, http.StatusBadRequest)
+	}
+}
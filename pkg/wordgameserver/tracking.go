@@ -0,0 +1,82 @@
+package wordgameserver
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// UnseenTilesResponse is the format of the response sent to clients when they
+// request the tiles they have not yet seen
+type UnseenTilesResponse struct {
+	GameID     uuid.UUID      `json:"game_id"`
+	Tiles      map[string]int `json:"tiles"`      // unseen count per letter, blanks keyed by " "
+	Total      int            `json:"total"`      // unseen tiles in the bag and opponents' racks
+	InBag      int            `json:"in_bag"`     // tiles remaining in the bag
+	Vowels     int            `json:"vowels"`     // unseen A, E, I, O and U tiles
+	Consonants int            `json:"consonants"` // unseen letters that are not vowels
+	Blanks     int            `json:"blanks"`     // unseen blank tiles
+}
+
+// isVowel reports whether the letter is one of the five vowels
+func isVowel(l byte) bool {
+	switch l {
+	case 'A', 'E', 'I', 'O', 'U':
+		return true
+	}
+	return false
+}
+
+// unseenTiles removes the tiles on the board and in the player's rack from the
+// full tile distribution, leaving the tiles the player has not seen
+func unseenTiles(s GameStateResponse) (UnseenTilesResponse, error) {
+	counts := make(map[byte]int, len(tiles))
+	for l, t := range tiles {
+		counts[l] = t.Count
+	}
+
+	// Remove tiles on the board. Blanks keep the value of zero when played.
+	for _, row := range s.Board {
+		for _, square := range row {
+			if square.Letter == 0 {
+				continue
+			}
+			if square.Value == 0 {
+				counts[' ']--
+			} else {
+				counts[square.Letter]--
+			}
+		}
+	}
+
+	// Remove tiles in the player's rack, and their teammate's if they can see it
+	for _, t := range s.PlayerTiles {
+		counts[t]--
+	}
+	for _, t := range s.TeammateTiles {
+		counts[t]--
+	}
+
+	u := UnseenTilesResponse{
+		GameID: s.GameID,
+		Tiles:  make(map[string]int, len(counts)),
+		InBag:  s.TilesRemaining,
+	}
+
+	for l, c := range counts {
+		if c < 0 {
+			return u, errors.New("More '" + string(l) + "' tiles seen than exist in game")
+		}
+		u.Tiles[string(l)] = c
+		u.Total += c
+		switch {
+		case l == ' ':
+			u.Blanks += c
+		case isVowel(l):
+			u.Vowels += c
+		default:
+			u.Consonants += c
+		}
+	}
+
+	return u, nil
+}
@@ -0,0 +1,120 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUnseenTiles(t *testing.T) {
+	s := GameStateResponse{
+		Board:          initializedBoard,
+		PlayerTiles:    []byte{'A', 'E', 'Q', ' '},
+		TilesRemaining: 80,
+	}
+	s.Board[7][7].Tile = Tile{Letter: 'Z', Value: 10}
+	s.Board[7][8].Tile = Tile{Letter: 'A', Value: 0}
+
+	u, err := unseenTiles(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]int{
+		" ": 0,
+		"A": 8,
+		"E": 11,
+		"Q": 0,
+		"Z": 0,
+	}
+	for l, c := range expected {
+		if u.Tiles[l] != c {
+			t.Errorf("Expected %v unseen '%v' tiles, got %v", c, l, u.Tiles[l])
+		}
+	}
+
+	if u.Total != len(initializedTileBag)-6 {
+		t.Errorf("Expected %v unseen tiles, got %v", len(initializedTileBag)-6, u.Total)
+	} else if u.Vowels+u.Consonants+u.Blanks != u.Total {
+		t.Error("Vowel, consonant and blank counts do not add up to total")
+	} else if u.Vowels != 40 {
+		t.Errorf("Expected 40 unseen vowels, got %v", u.Vowels)
+	} else if u.InBag != 80 {
+		t.Errorf("Expected 80 tiles in bag, got %v", u.InBag)
+	}
+}
+
+func TestUnseenTilesHandler(t *testing.T) {
+	newGame := createScrabbleGame()
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	var playerID uuid.UUID
+	for _, name := range []string{"ashley1", "ashley2"} {
+		playerID, _ = newGame.addPlayer(name)
+	}
+
+	j := GeneralGameRequest{
+		GameID:   newGame.ID,
+		PlayerID: &playerID,
+	}
+
+	payload, err := json.Marshal(j)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Should fail before the game starts
+	if rr := unseenRequest(t, payload); rr.Code == http.StatusOK {
+		t.Fatal("Unseen tiles should not be available before game starts")
+	}
+
+	newGame.start()
+
+	rr := unseenRequest(t, payload)
+	if c := rr.Code; c != http.StatusOK {
+		t.Fatalf("Returned status code %v, expected %v. Error: %v",
+			c, http.StatusOK, rr.Body)
+	}
+
+	var u UnseenTilesResponse
+	err = json.NewDecoder(rr.Body).Decode(&u)
+	if err != nil {
+		t.Fatal("Response was not in correct format")
+	}
+
+	if u.Total != len(initializedTileBag)-maxTiles {
+		t.Errorf("Expected %v unseen tiles, got %v", len(initializedTileBag)-maxTiles, u.Total)
+	} else if u.InBag != len(initializedTileBag)-2*maxTiles {
+		t.Errorf("Expected %v tiles in bag, got %v", len(initializedTileBag)-2*maxTiles, u.InBag)
+	}
+
+	// Should be forbidden when disabled by the game's rules
+	newGame.Lock()
+	newGame.Rules.DisableTileTracking = true
+	newGame.Unlock()
+
+	if rr := unseenRequest(t, payload); rr.Code != http.StatusForbidden {
+		t.Fatalf("Returned status code %v, expected %v", rr.Code, http.StatusForbidden)
+	}
+}
+
+func unseenRequest(t *testing.T, payload []byte) *httptest.ResponseRecorder {
+	req, err := http.NewRequest("GET", "/game/unseen", bytes.NewBuffer(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	h := http.HandlerFunc(unseenTilesHandler)
+
+	h.ServeHTTP(rr, req)
+
+	return rr
+}
@@ -0,0 +1,298 @@
+package wordgameserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// moveTypes are the history events that are moves made by players, as listed
+// by GET /v2/games/{id}/moves
+var moveTypes = map[string]bool{
+	"play": true,
+	"swap": true,
+	"pass": true,
+	"peel": true,
+	"dump": true,
+}
+
+// GameResource is a game as represented by the v2 API. State is only included
+// once the game has started, and only for the player whose ID is given.
+type GameResource struct {
+	GameID  uuid.UUID          `json:"game_id"`
+	Rules   GameRules          `json:"rules"`
+	Started bool               `json:"started"`
+	Locked  bool               `json:"locked"`
+	Players []string           `json:"players"`         // names in turn order
+	State   *GameStateResponse `json:"state,omitempty"` // game as seen by the player
+}
+
+// gameResource describes a game, including its state as seen by the player if
+// a player ID is given and the game has started. The player is looked up under
+// the same lock as the rest of the game, so players removed before the start
+// are not found.
+func gameResource(gameID uuid.UUID, playerID *uuid.UUID) (GameResource, error) {
+	g, err := findGame(gameID)
+	if err != nil {
+		return GameResource{}, err
+	}
+
+	g.Lock()
+	res := GameResource{
+		GameID:  g.ID,
+		Rules:   g.Rules,
+		Started: g.Active,
+		Locked:  g.Locked,
+		Players: make([]string, len(g.Players)),
+	}
+	for _, p := range g.Players {
+		res.Players[p.Number] = p.Name
+	}
+	isPlayer := false
+	if playerID != nil {
+		_, isPlayer = g.Players[*playerID]
+	}
+	g.Unlock()
+
+	if playerID == nil {
+		return res, nil
+	} else if !isPlayer {
+		return res, errPlayerNotFound
+	}
+
+	if res.Started {
+		state, err := g.request(GamePlayRequest{GameID: gameID, PlayerID: *playerID})
+		if err != nil {
+			return res, err
+		}
+		res.State = &state
+	}
+	return res, nil
+}
+
+// registerV2 registers the v2 API, in which games are resources addressed by
+// their path. Unlike the original endpoints, each route only accepts the
+// methods it documents, everything under /v2 responds with JSON errors, and no
+// request waits for the game to start.
+func registerV2(r *mux.Router) {
+	r.HandleFunc("/games", createGameV2Handler).Methods(http.MethodPost)
+	r.HandleFunc("/games/{id}", getGameV2Handler).Methods(http.MethodGet)
+	r.HandleFunc("/games/{id}/players", addPlayerV2Handler).Methods(http.MethodPost)
+	r.HandleFunc("/games/{id}/start", startGameV2Handler).Methods(http.MethodPost)
+	r.HandleFunc("/games/{id}/moves", playMoveV2Handler).Methods(http.MethodPost)
+	r.HandleFunc("/games/{id}/moves", listMovesV2Handler).Methods(http.MethodGet)
+
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, newError(CodeNotFound, "No endpoint at "+r.URL.Path))
+	})
+	r.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, newError(CodeMethodNotAllowed, r.Method+" is not allowed on "+r.URL.Path))
+	})
+}
+
+// pathGameID parses the game ID in the request's path, responding with an
+// error if no game could have that ID
+func pathGameID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, errGameNotFound)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// queryPlayerID parses the optional player_id query parameter, responding
+// with an error if it is not an ID
+func queryPlayerID(w http.ResponseWriter, r *http.Request) (*uuid.UUID, bool) {
+	p := r.URL.Query().Get("player_id")
+	if p == "" {
+		return nil, true
+	}
+
+	id, err := uuid.Parse(p)
+	if err != nil {
+		writeError(w, err)
+		return nil, false
+	}
+	return &id, true
+}
+
+// writeJSON responds with v encoded as JSON
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	resp, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, internalError(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	w.Write(resp)
+}
+
+// createGameV2Handler handles POST /v2/games. The body is optional and may
+// contain the rules and the name of the host, as for /game/create. The new
+// game's path is sent in the Location header.
+func createGameV2Handler(w http.ResponseWriter, r *http.Request) {
+	var j GeneralGameRequest
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil && err != io.EOF {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := createGame(j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", "/v2/games/"+resp.GameID.String())
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// getGameV2Handler handles GET /v2/games/{id}. Players pass their player_id
+// as a query parameter to receive their state once the game has started.
+func getGameV2Handler(w http.ResponseWriter, r *http.Request) {
+	gameID, ok := pathGameID(w, r)
+	if !ok {
+		return
+	}
+
+	playerID, ok := queryPlayerID(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := gameResource(gameID, playerID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// addPlayerV2Handler handles POST /v2/games/{id}/players, which adds the
+// player named in the body and responds with their ID
+func addPlayerV2Handler(w http.ResponseWriter, r *http.Request) {
+	var j GeneralGameRequest
+
+	gameID, ok := pathGameID(w, r)
+	if !ok {
+		return
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return
+	} else if j.PlayerName == nil {
+		writeError(w, errPlayerNameRequired)
+		return
+	}
+
+	playerID, err := joinGame(gameID, *j.PlayerName, j.Team)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, GeneralGameRequest{
+		GameID:     gameID,
+		PlayerID:   &playerID,
+		PlayerName: j.PlayerName,
+	})
+}
+
+// startGameV2Handler handles POST /v2/games/{id}/start. The body must contain
+// the host's player_id.
+func startGameV2Handler(w http.ResponseWriter, r *http.Request) {
+	var j GeneralGameRequest
+
+	gameID, ok := pathGameID(w, r)
+	if !ok {
+		return
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	err = hostStartGame(gameID, j.PlayerID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// playMoveV2Handler handles POST /v2/games/{id}/moves, which plays, swaps or
+// passes as for /game/play. The game ID in the path is used over any in the
+// body.
+func playMoveV2Handler(w http.ResponseWriter, r *http.Request) {
+	var j GamePlayRequest
+
+	gameID, ok := pathGameID(w, r)
+	if !ok {
+		return
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	j.GameID = gameID
+	j.Play = true
+	state, err := requestGame(j)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, state)
+}
+
+// listMovesV2Handler handles GET /v2/games/{id}/moves, which lists the moves
+// made so far in order. Players pass their player_id as a query parameter to
+// see their own racks before the game is over.
+func listMovesV2Handler(w http.ResponseWriter, r *http.Request) {
+	gameID, ok := pathGameID(w, r)
+	if !ok {
+		return
+	}
+
+	playerID, ok := queryPlayerID(w, r)
+	if !ok {
+		return
+	}
+
+	g, err := getGame(gameID, w)
+	if err != nil {
+		return
+	}
+
+	events, err := g.visibleHistory(playerID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	moves := make([]GameEvent, 0)
+	for _, e := range events {
+		if moveTypes[e.Type] {
+			moves = append(moves, e)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, moves)
+}
@@ -0,0 +1,164 @@
+package wordgameserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// v2Request sends a request through the router, with the body encoded as JSON
+// if it is not nil
+func v2Request(t *testing.T, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	newRouter().ServeHTTP(rr, httptest.NewRequest(method, path, &payload))
+	return rr
+}
+
+// decodeResponse decodes a successful response, failing if the status is not
+// the one expected
+func decodeResponse(t *testing.T, rr *httptest.ResponseRecorder, status int, v interface{}) {
+	t.Helper()
+
+	if rr.Code != status {
+		t.Fatalf("Status %v, expected %v. Body: %v", rr.Code, status, rr.Body)
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), v); err != nil {
+		t.Fatalf("Response %q: %v", rr.Body, err)
+	}
+}
+
+func TestV2Game(t *testing.T) {
+	ann, ben := "Ann", "Ben"
+
+	var created GeneralGameRequest
+	rr := v2Request(t, http.MethodPost, "/v2/games", GeneralGameRequest{PlayerName: &ann})
+	decodeResponse(t, rr, http.StatusCreated, &created)
+	path := "/v2/games/" + created.GameID.String()
+	if loc := rr.Header().Get("Location"); loc != path {
+		t.Errorf("Location is %q, expected %q", loc, path)
+	}
+	host := *created.PlayerID
+
+	var joined GeneralGameRequest
+	rr = v2Request(t, http.MethodPost, path+"/players", GeneralGameRequest{PlayerName: &ben})
+	decodeResponse(t, rr, http.StatusCreated, &joined)
+	guest := *joined.PlayerID
+
+	// Before the game starts, players are listed but there is no state
+	var game GameResource
+	decodeResponse(t, v2Request(t, http.MethodGet, path+"?player_id="+host.String(), nil), http.StatusOK, &game)
+	if game.Started || game.State != nil || len(game.Players) != 2 || game.Players[0] != ann {
+		t.Errorf("Game before starting is %+v", game)
+	}
+
+	rr = v2Request(t, http.MethodPost, path+"/start", GeneralGameRequest{PlayerID: &guest})
+	if rr.Code != http.StatusForbidden || responseError(t, rr).Code != CodeNotHost {
+		t.Errorf("Guest starting the game: status %v, body %v", rr.Code, rr.Body)
+	}
+	rr = v2Request(t, http.MethodPost, path+"/start", GeneralGameRequest{PlayerID: &host})
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("Starting the game: status %v, body %v", rr.Code, rr.Body)
+	}
+
+	game = GameResource{}
+	decodeResponse(t, v2Request(t, http.MethodGet, path+"?player_id="+host.String(), nil), http.StatusOK, &game)
+	if !game.Started || game.State == nil {
+		t.Fatalf("Game after starting is %+v", game)
+	}
+
+	// Whoever is first passes, then can't go again
+	first := map[string]uuid.UUID{ann: host, ben: guest}[game.Players[game.State.PlayerTurn]]
+	var state GameStateResponse
+	rr = v2Request(t, http.MethodPost, path+"/moves", GamePlayRequest{PlayerID: first, Move: "-"})
+	decodeResponse(t, rr, http.StatusCreated, &state)
+
+	rr = v2Request(t, http.MethodPost, path+"/moves", GamePlayRequest{PlayerID: first, Move: "-"})
+	if rr.Code != http.StatusConflict || responseError(t, rr).Code != CodeNotYourTurn {
+		t.Errorf("Playing out of turn: status %v, body %v", rr.Code, rr.Body)
+	}
+
+	var moves []GameEvent
+	decodeResponse(t, v2Request(t, http.MethodGet, path+"/moves", nil), http.StatusOK, &moves)
+	if len(moves) != 1 || moves[0].Type != "pass" {
+		t.Errorf("Moves are %+v, expected a pass", moves)
+	}
+
+	// The original API works on the same game
+	rr = v2Request(t, http.MethodPost, "/game/history", GeneralGameRequest{GameID: created.GameID})
+	decodeResponse(t, rr, http.StatusOK, &moves)
+	if len(moves) == 0 {
+		t.Error("History is empty")
+	}
+}
+
+func TestV2Errors(t *testing.T) {
+	missing := "/v2/games/" + uuid.New().String()
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+		code   ErrorCode
+	}{
+		{http.MethodGet, missing, http.StatusNotFound, CodeGameNotFound},
+		{http.MethodGet, "/v2/games/not-an-id", http.StatusNotFound, CodeGameNotFound},
+		{http.MethodGet, missing + "/moves", http.StatusNotFound, CodeGameNotFound},
+		{http.MethodDelete, missing, http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+		{http.MethodGet, "/v2/games", http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+		{http.MethodGet, "/v2/players", http.StatusNotFound, CodeNotFound},
+	}
+
+	for _, test := range tests {
+		rr := v2Request(t, test.method, test.path, nil)
+		if rr.Code != test.status {
+			t.Errorf("%s %s: status %v, expected %v", test.method, test.path, rr.Code, test.status)
+		} else if e := responseError(t, rr); e.Code != test.code {
+			t.Errorf("%s %s: code %v, expected %v", test.method, test.path, e.Code, test.code)
+		}
+	}
+}
+
+func TestV2KickedPlayer(t *testing.T) {
+	newGame := createScrabbleGame()
+	hostID, _ := newGame.addPlayer("ashley1")
+	newGame.addPlayer("ashley2")
+	kickedID, _ := newGame.addPlayer("ashley3")
+	newGame.HostID = hostID
+
+	serverMu.Lock()
+	server.activeGames[newGame.ID] = newGame
+	serverMu.Unlock()
+
+	newGame.Lock()
+	err := newGame.removePlayer(kickedID)
+	newGame.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := newGame.start(); err != nil {
+		t.Fatal(err)
+	}
+
+	path := "/v2/games/" + newGame.ID.String()
+	rr := serveWithin(t, http.MethodGet, path+"?player_id="+kickedID.String(), nil)
+	if rr.Code != http.StatusNotFound || responseError(t, rr).Code != CodePlayerNotFound {
+		t.Errorf("Kicked player's game: status %v, body %v", rr.Code, rr.Body)
+	}
+	rr = serveWithin(t, http.MethodPost, path+"/moves", GamePlayRequest{PlayerID: kickedID, Move: "-"})
+	if rr.Code != http.StatusNotFound || responseError(t, rr).Code != CodePlayerNotFound {
+		t.Errorf("Kicked player's move: status %v, body %v", rr.Code, rr.Body)
+	}
+}
@@ -0,0 +1,23 @@
+package wordgameserver
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// webFiles is the browser client, built into the binary so the server can be
+// used without any other tools
+//
+//go:embed web
+var webFiles embed.FS
+
+// webHandler serves the browser client. Its pages use the same JSON endpoints
+// as any other client.
+func webHandler() http.Handler {
+	files, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err)
+	}
+	return http.FileServer(http.FS(files))
+}
@@ -0,0 +1,648 @@
+// Browser client for the word game server. It only uses the server's JSON
+// endpoints, and the server-sent events from /game/watch for updates.
+'use strict';
+
+const letterValues = {
+  A: 1, B: 3, C: 3, D: 2, E: 1, F: 4, G: 2, H: 4, I: 1, J: 8, K: 5, L: 1, M: 3,
+  N: 1, O: 1, P: 3, Q: 10, R: 1, S: 1, T: 1, U: 1, V: 4, W: 4, X: 8, Y: 4, Z: 10,
+};
+
+const squareLabels = {
+  star: '★',
+  doubleLetter: 'DL',
+  tripleLetter: 'TL',
+  doubleWord: 'DW',
+  tripleWord: 'TW',
+};
+
+// session is everything the client knows about the game being shown. Players
+// have a player ID, spectators don't.
+const session = {
+  gameId: null,
+  playerId: null,
+  name: '',
+  host: false,
+  started: false,
+  board: null,
+  players: [],
+  turn: 0,
+  winner: '',
+  rack: [], // tiles as {letter, blank, square, as}, where square is set once placed
+  teammateRack: '', // teammate's tiles, if the rules let teammates share racks
+  selected: null,
+  swapping: false,
+  events: null,
+  waiting: null,
+  previousBoard: null, // board before the last play, to highlight it
+};
+
+const $ = (id) => document.getElementById(id);
+
+// api posts a JSON request and returns the decoded response. Errors are
+// thrown with the server's message.
+async function api(path, body) {
+  const res = await fetch(path, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json' },
+    body: JSON.stringify(body || {}),
+  });
+  const text = await res.text();
+  if (!res.ok) {
+    let message = res.statusText;
+    try {
+      message = JSON.parse(text).error.message || message;
+    } catch (e) {
+      // not an error response, so keep the status
+    }
+    throw new Error(message);
+  }
+  try {
+    return JSON.parse(text);
+  } catch (e) {
+    return text;
+  }
+}
+
+function showError(err) {
+  const el = $('error');
+  if (!err) {
+    el.hidden = true;
+    return;
+  }
+  el.textContent = err.message || String(err);
+  el.hidden = false;
+}
+
+// run calls an action, showing any error it throws
+async function run(action) {
+  showError(null);
+  try {
+    await action();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function playerName() {
+  const name = $('name').value.trim();
+  if (!name) {
+    throw new Error('Enter your name first');
+  }
+  localStorage.setItem('name', name);
+  return name;
+}
+
+function me() {
+  return session.players.find((p) => p.name === session.name);
+}
+
+function myTurn() {
+  const p = me();
+  return session.playerId !== null && session.started && !session.winner &&
+    p !== undefined && p.number === session.turn;
+}
+
+// Lobby
+
+async function refreshLobby() {
+  const games = await api('/lobby/games');
+  const body = $('open-games').querySelector('tbody');
+  body.textContent = '';
+  for (const g of games) {
+    const row = body.insertRow();
+    row.insertCell().textContent = g.players.join(', ');
+    row.insertCell().textContent = g.seats;
+    const join = document.createElement('button');
+    join.textContent = 'Join';
+    join.className = 'small';
+    join.addEventListener('click', () => run(() => joinGame(g.game_id)));
+    row.insertCell().appendChild(join);
+  }
+  $('no-games').hidden = games.length > 0;
+}
+
+async function createGame() {
+  const name = playerName();
+  const resp = await api('/game/create', { player_name: name });
+  enterGame(resp.game_id, resp.player_id, name, true);
+}
+
+async function joinGame(gameId) {
+  const name = playerName();
+  const resp = await api('/game/join', { game_id: gameId, player_name: name });
+  enterGame(gameId, resp.player_id, name, false);
+}
+
+function watchGame(gameId) {
+  enterGame(gameId, null, $('name').value.trim(), false);
+}
+
+function gameIdInput() {
+  const id = $('game-id').value.trim();
+  if (!id) {
+    throw new Error('Enter a game ID');
+  }
+  return id;
+}
+
+// Game
+
+function enterGame(gameId, playerId, name, host) {
+  Object.assign(session, {
+    gameId, playerId, name, host,
+    started: false, board: null, players: [], turn: 0, winner: '',
+    rack: [], teammateRack: '', selected: null, swapping: false, previousBoard: null,
+  });
+
+  const params = new URLSearchParams({ game: gameId });
+  if (playerId) {
+    params.set('player', playerId);
+    params.set('name', name);
+  }
+  if (host) {
+    params.set('host', '1');
+  }
+  history.replaceState(null, '', '#' + params.toString());
+
+  $('lobby').hidden = true;
+  $('game').hidden = false;
+  $('game-label').textContent = gameId;
+  $('chat').textContent = '';
+  $('controls').hidden = playerId === null;
+
+  watch();
+  waitForStart();
+  render();
+}
+
+function leaveGame() {
+  if (session.events) {
+    session.events.close();
+  }
+  clearInterval(session.waiting);
+  session.gameId = null;
+  history.replaceState(null, '', location.pathname);
+  $('game').hidden = true;
+  $('lobby').hidden = false;
+  run(refreshLobby);
+}
+
+// watch subscribes to the game's updates. The first update arrives when the
+// game starts, or straight away if it already has.
+function watch() {
+  const params = new URLSearchParams({ game_id: session.gameId });
+  if (session.playerId) {
+    params.set('player_id', session.playerId);
+  }
+  session.events = new EventSource('/game/watch?' + params.toString());
+  session.events.addEventListener('state', (e) => {
+    const update = JSON.parse(e.data);
+    if (update.racks) {
+      return; // delayed racks for spectators, with an old board
+    }
+    session.started = true;
+    setBoard(update.board);
+    session.players = update.players;
+    session.turn = update.turn;
+    $('bag').textContent = update.tiles_remaining;
+    if (session.playerId) {
+      run(refreshState);
+    }
+    render();
+  });
+  session.events.addEventListener('chat', (e) => addChat(JSON.parse(e.data)));
+  session.events.addEventListener('error', () => {
+    if (session.events.readyState === EventSource.CLOSED) {
+      showError(new Error('Lost connection to the game'));
+    }
+  });
+}
+
+// waitForStart shows who has joined from the lobby until the game starts
+function waitForStart() {
+  clearInterval(session.waiting);
+  const poll = async () => {
+    if (session.started) {
+      clearInterval(session.waiting);
+      return;
+    }
+    const games = await api('/lobby/games');
+    const g = games.find((g) => g.game_id === session.gameId);
+    if (g && !session.started) {
+      session.players = g.players.map((name, i) => ({ name, number: i, score: 0 }));
+      render();
+    }
+  };
+  session.waiting = setInterval(() => poll().catch(() => {}), 3000);
+  poll().catch(() => {});
+}
+
+// refreshState fetches the player's own view of the game, which includes
+// their rack
+async function refreshState() {
+  applyState(await api('/game/state', {
+    game_id: session.gameId,
+    player_id: session.playerId,
+  }));
+}
+
+// setBoard replaces the board, remembering the old one if it changed
+function setBoard(board) {
+  if (session.board && JSON.stringify(session.board) !== JSON.stringify(board)) {
+    session.previousBoard = session.board;
+  }
+  session.board = board;
+}
+
+function applyState(state) {
+  setBoard(state.board);
+  session.players = state.players;
+  session.turn = state.turn;
+  session.winner = state.winner || '';
+  $('bag').textContent = state.tiles_remaining;
+
+  session.teammateRack = atob(state.teammate_tiles || '');
+
+  const letters = atob(state.tiles || '');
+  const current = session.rack.map((t) => (t.blank ? ' ' : t.letter)).sort().join('');
+  if (current !== letters.split('').sort().join('')) {
+    session.rack = letters.split('').map((l) => ({
+      letter: l === ' ' ? '' : l,
+      blank: l === ' ',
+      square: null,
+      as: '',
+    }));
+    session.selected = null;
+  }
+  render();
+}
+
+async function startGame() {
+  await api('/game/start', { game_id: session.gameId, player_id: session.playerId });
+}
+
+async function submitPlay() {
+  const placed = session.rack.filter((t) => t.square);
+  if (placed.length === 0) {
+    throw new Error('Place some tiles on the board first');
+  }
+  placed.sort((a, b) => a.square.row - b.square.row || a.square.col - b.square.col);
+
+  const first = placed[0].square;
+  const across = placed.every((t) => t.square.row === first.row);
+  const down = placed.every((t) => t.square.col === first.col);
+  if (!across && !down) {
+    throw new Error('Tiles must be in a single row or column');
+  }
+
+  const tiles = placed.map((t) => (t.blank ? ' ' : t.letter)).join('');
+  const blanks = placed.filter((t) => t.blank).map((t) => t.as).join('');
+  const state = await api('/game/play', {
+    game_id: session.gameId,
+    player_id: session.playerId,
+    start_pos: first,
+    end_pos: placed[placed.length - 1].square,
+    tiles: btoa(tiles),
+    blanks: blanks ? btoa(blanks) : undefined,
+  });
+  session.rack = [];
+  applyState(state);
+}
+
+async function swapOrPass(move) {
+  const state = await api('/game/play', {
+    game_id: session.gameId,
+    player_id: session.playerId,
+    move,
+  });
+  session.rack = [];
+  session.swapping = false;
+  applyState(state);
+}
+
+async function toggleSwap() {
+  if (!session.swapping) {
+    recall();
+    session.swapping = true;
+    session.selected = null;
+    render();
+    return;
+  }
+
+  const chosen = session.rack.filter((t) => t.chosen);
+  session.rack.forEach((t) => { t.chosen = false; });
+  if (chosen.length === 0) {
+    session.swapping = false;
+    render();
+    return;
+  }
+  await swapOrPass('-' + chosen.map((t) => (t.blank ? '?' : t.letter)).join(''));
+}
+
+async function pass() {
+  if (confirm('Pass your turn?')) {
+    await swapOrPass('-');
+  }
+}
+
+function recall() {
+  session.rack.forEach((t) => {
+    t.square = null;
+    if (t.blank) {
+      t.as = '';
+    }
+  });
+  session.selected = null;
+  render();
+}
+
+function shuffle() {
+  const rack = session.rack;
+  for (let i = rack.length - 1; i > 0; i--) {
+    const j = Math.floor(Math.random() * (i + 1));
+    [rack[i], rack[j]] = [rack[j], rack[i]];
+  }
+  render();
+}
+
+// placeTile puts a rack tile on an empty square, asking which letter a blank
+// stands for
+function placeTile(tile, row, col) {
+  if (!squareFree(row, col)) {
+    return;
+  }
+  if (tile.blank && !tile.as) {
+    const letter = (prompt('Which letter is the blank?') || '').trim().toUpperCase();
+    if (!/^[A-Z]$/.test(letter)) {
+      return;
+    }
+    tile.as = letter;
+  }
+  tile.square = { row, col };
+  session.selected = null;
+  render();
+}
+
+function squareFree(row, col) {
+  const square = session.board && session.board[row][col];
+  if (!square || (square.tile && square.tile.letter)) {
+    return false;
+  }
+  return !session.rack.some((t) => t.square && t.square.row === row && t.square.col === col);
+}
+
+// Chat
+
+function addChat(m) {
+  const item = document.createElement('li');
+  const sender = document.createElement('span');
+  sender.className = m.spectator ? 'sender spectator' : 'sender';
+  sender.textContent = m.sender + ': ';
+  item.appendChild(sender);
+  item.appendChild(document.createTextNode(m.text));
+  $('chat').appendChild(item);
+  $('chat').scrollTop = $('chat').scrollHeight;
+}
+
+async function postChat(text) {
+  const req = { game_id: session.gameId, text };
+  if (session.playerId) {
+    req.player_id = session.playerId;
+  } else {
+    req.spectator_name = session.name || 'Spectator';
+  }
+  await api('/game/chat/post', req);
+}
+
+// Rendering
+
+function tileElement(letter, blank) {
+  const el = document.createElement('div');
+  el.className = blank ? 'tile blank' : 'tile';
+  el.textContent = blank ? letter.toLowerCase() : letter;
+  if (!blank) {
+    const value = document.createElement('span');
+    value.className = 'value';
+    value.textContent = letterValues[letter] || '';
+    el.appendChild(value);
+  }
+  return el;
+}
+
+// dragged remembers the rack tile being dragged, since drag data can't be
+// read until the drop
+let dragged = null;
+
+function draggable(el, tile) {
+  el.draggable = true;
+  el.addEventListener('dragstart', (e) => {
+    dragged = tile;
+    e.dataTransfer.setData('text/plain', tile.letter || '?');
+    e.dataTransfer.effectAllowed = 'move';
+  });
+  el.addEventListener('dragend', () => { dragged = null; });
+}
+
+function renderBoard() {
+  const boardEl = $('board');
+  boardEl.textContent = '';
+  const board = session.board;
+
+  // Highlight the tiles from the last play
+  const previous = session.previousBoard;
+
+  for (let row = 0; row < 15; row++) {
+    for (let col = 0; col < 15; col++) {
+      const square = board ? board[row][col] : { type: row === 7 && col === 7 ? 'star' : 'plain' };
+      const el = document.createElement('div');
+      el.className = 'square ' + square.type;
+
+      const letter = square.tile && square.tile.letter;
+      const tile = session.rack.find((t) => t.square && t.square.row === row && t.square.col === col);
+      if (letter) {
+        const l = String.fromCharCode(letter);
+        el.appendChild(tileElement(l, square.tile.value === 0));
+        const old = previous && previous[row][col].tile;
+        if (previous && !(old && old.letter)) {
+          el.classList.add('last');
+        }
+      } else if (tile) {
+        const t = tileElement(tile.blank ? tile.as : tile.letter, tile.blank);
+        t.classList.add('placed');
+        t.title = 'Click or drag back to the rack';
+        draggable(t, tile);
+        t.addEventListener('click', (e) => {
+          e.stopPropagation();
+          tile.square = null;
+          if (tile.blank) {
+            tile.as = '';
+          }
+          render();
+        });
+        el.appendChild(t);
+      } else {
+        el.textContent = squareLabels[square.type] || '';
+        el.addEventListener('dragover', (e) => {
+          if (dragged) {
+            e.preventDefault();
+          }
+        });
+        el.addEventListener('drop', (e) => {
+          e.preventDefault();
+          if (dragged) {
+            placeTile(dragged, row, col);
+          }
+        });
+        el.addEventListener('click', () => {
+          if (session.selected) {
+            placeTile(session.selected, row, col);
+          }
+        });
+      }
+      boardEl.appendChild(el);
+    }
+  }
+}
+
+function renderRack() {
+  const rackEl = $('rack');
+  rackEl.textContent = '';
+  for (const tile of session.rack) {
+    if (tile.square) {
+      continue;
+    }
+    const el = tileElement(tile.letter, false);
+    if (tile.blank) {
+      el.textContent = '';
+    }
+    if (tile === session.selected || (session.swapping && tile.chosen)) {
+      el.classList.add('selected');
+    }
+    draggable(el, tile);
+    el.addEventListener('click', () => {
+      if (session.swapping) {
+        tile.chosen = !tile.chosen;
+      } else {
+        session.selected = session.selected === tile ? null : tile;
+      }
+      render();
+    });
+    rackEl.appendChild(el);
+  }
+
+  const teammateEl = $('teammate-rack');
+  teammateEl.textContent = '';
+  for (const l of session.teammateRack) {
+    teammateEl.appendChild(tileElement(l === ' ' ? '' : l, l === ' '));
+  }
+  $('teammate').hidden = session.teammateRack === '';
+}
+
+function renderScores() {
+  const body = $('scores').querySelector('tbody');
+  body.textContent = '';
+  for (const p of session.players) {
+    const row = body.insertRow();
+    if (session.started && !session.winner && p.number === session.turn) {
+      row.className = 'turn';
+    }
+    let name = p.name;
+    if (session.playerId && p.name === session.name) {
+      name += ' (you)';
+    }
+    if (p.forfeited) {
+      name += ' – forfeited';
+    }
+    row.insertCell().textContent = name;
+    row.insertCell().textContent = p.score;
+  }
+}
+
+function renderStatus() {
+  let status;
+  if (session.winner) {
+    status = 'Game over. ' + session.winner + ' won!';
+  } else if (!session.started) {
+    status = session.host ? 'Start the game when everyone has joined.' : 'Waiting for the host to start the game.';
+  } else if (myTurn()) {
+    status = 'Your turn!';
+  } else {
+    const p = session.players.find((p) => p.number === session.turn);
+    status = p ? p.name + ' is playing.' : '';
+  }
+  $('status').textContent = status;
+
+  $('start').hidden = !session.host || session.started;
+  const turn = myTurn();
+  $('play').disabled = !turn || session.swapping;
+  $('swap').disabled = !turn;
+  $('pass').disabled = !turn || session.swapping;
+  $('swap').textContent = session.swapping ? 'Swap selected' : 'Swap';
+}
+
+function render() {
+  renderBoard();
+  renderRack();
+  renderScores();
+  renderStatus();
+}
+
+// Setup
+
+function setup() {
+  $('name').value = localStorage.getItem('name') || '';
+
+  $('create').addEventListener('click', () => run(createGame));
+  $('join').addEventListener('click', () => run(() => joinGame(gameIdInput())));
+  $('watch').addEventListener('click', () => run(() => watchGame(gameIdInput())));
+  $('refresh').addEventListener('click', () => run(refreshLobby));
+
+  $('start').addEventListener('click', () => run(startGame));
+  $('play').addEventListener('click', () => run(submitPlay));
+  $('recall').addEventListener('click', recall);
+  $('shuffle').addEventListener('click', shuffle);
+  $('swap').addEventListener('click', () => run(toggleSwap));
+  $('pass').addEventListener('click', () => run(pass));
+  $('leave').addEventListener('click', leaveGame);
+  $('copy-link').addEventListener('click', () => {
+    const link = location.origin + location.pathname + '#game=' + session.gameId;
+    navigator.clipboard.writeText(link).catch(() => prompt('Share this link to watch the game:', link));
+  });
+
+  // Tiles dragged off the board go back to the rack
+  $('rack').addEventListener('dragover', (e) => {
+    if (dragged && dragged.square) {
+      e.preventDefault();
+    }
+  });
+  $('rack').addEventListener('drop', (e) => {
+    e.preventDefault();
+    if (dragged) {
+      dragged.square = null;
+      if (dragged.blank) {
+        dragged.as = '';
+      }
+      render();
+    }
+  });
+
+  $('chat-form').addEventListener('submit', (e) => {
+    e.preventDefault();
+    const text = $('chat-text').value.trim();
+    if (text) {
+      $('chat-text').value = '';
+      run(() => postChat(text));
+    }
+  });
+
+  // Links carry the game, and the player if they are rejoining
+  const params = new URLSearchParams(location.hash.slice(1));
+  if (params.get('game')) {
+    enterGame(params.get('game'), params.get('player'),
+      params.get('name') || $('name').value.trim(), params.get('host') === '1');
+  } else {
+    run(refreshLobby);
+  }
+}
+
+setup();
@@ -0,0 +1,34 @@
+package wordgameserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebHandler(t *testing.T) {
+	h := webHandler()
+
+	for path, want := range map[string]string{
+		"/":        `<script src="app.js">`,
+		"/app.js":  "/game/watch?",
+		"/app.css": ".board",
+	} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: status %d", path, w.Code)
+			continue
+		}
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("%s does not contain %q", path, want)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing.js", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Missing file: status %d, expected %d", w.Code, http.StatusNotFound)
+	}
+}